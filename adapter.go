@@ -1,6 +1,9 @@
 package xlog
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 // Adapter is the pluggable strategy that emits logs. It must be concurrency-safe.
 // Patterns: Adapter + Strategy
@@ -11,3 +14,34 @@ type Adapter interface {
 	// Implementations MUST NOT retain or mutate the fields slice after return.
 	Log(level Level, msg string, at time.Time, fields []Field)
 }
+
+// adapterBorrowsFields is an optional interface adapters can implement to let
+// the Logger skip its defensive copy of event fields. BorrowsFields returning
+// true vouches that Log honors the contract above strictly, including in any
+// buffered or asynchronous path: fields is fully consumed before Log returns,
+// since the caller (e.g. a pooled Event) reuses the slice right after.
+// The copy is still made when observers, KeyPrefix, auto fields, auto stack
+// or lazy values are configured, as those need a slice the Logger owns.
+type adapterBorrowsFields interface {
+	BorrowsFields() bool
+}
+
+func borrowsFields(a Adapter) bool {
+	b, ok := a.(adapterBorrowsFields)
+	return ok && b.BorrowsFields()
+}
+
+// adapterWriterSetter is an optional interface for adapters whose output can
+// be redirected at runtime (see Logger.SetWriter).
+type adapterWriterSetter interface {
+	SetWriter(io.Writer)
+}
+
+// ObserverAdapter returns an Adapter that writes nothing, for loggers whose
+// only sinks are their observers (test harnesses, custom pipelines):
+//
+//	logger, _ := xlog.NewBuilder().
+//		WithAdapter(xlog.ObserverAdapter()).
+//		AddObserver(sink).
+//		Build()
+func ObserverAdapter() Adapter { return nopAdapter{} }
@@ -0,0 +1,64 @@
+// Package chanwriter provides an xlog.Adapter that delivers entries to a Go
+// channel, for composing in-process log-processing pipelines.
+package chanwriter
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// DropPolicy selects what Log does when the channel is full.
+type DropPolicy uint8
+
+const (
+	// DropNewest discards the entry being logged and counts it (default).
+	DropNewest DropPolicy = iota
+	// Block waits until the consumer makes room.
+	Block
+)
+
+// Adapter sends each entry, with bound and event fields merged, to a channel.
+// Entries own their Fields slice, so consumers may retain them.
+type Adapter struct {
+	ch      chan<- xlog.Entry
+	policy  DropPolicy
+	bound   []xlog.Field
+	dropped *atomic.Uint64 // shared with children
+}
+
+// New returns an adapter writing to ch using the given policy when ch is full.
+func New(ch chan<- xlog.Entry, onFull DropPolicy) *Adapter {
+	return &Adapter{ch: ch, policy: onFull, dropped: new(atomic.Uint64)}
+}
+
+// With returns a child adapter whose entries include fs after the parent's
+// bound fields. Children share the channel and drop counter.
+func (a *Adapter) With(fs []xlog.Field) xlog.Adapter {
+	child := *a
+	if len(fs) > 0 {
+		child.bound = append(append(make([]xlog.Field, 0, len(a.bound)+len(fs)), a.bound...), fs...)
+	}
+	return &child
+}
+
+// Log sends the entry, dropping it when the channel is full under DropNewest.
+func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.Field) {
+	e := xlog.Entry{Level: level, Message: msg, At: at}
+	if n := len(a.bound) + len(fields); n > 0 {
+		e.Fields = append(append(make([]xlog.Field, 0, n), a.bound...), fields...)
+	}
+	if a.policy == Block {
+		a.ch <- e
+		return
+	}
+	select {
+	case a.ch <- e:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// Dropped reports how many entries were discarded because the channel was full.
+func (a *Adapter) Dropped() uint64 { return a.dropped.Load() }
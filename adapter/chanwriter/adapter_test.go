@@ -0,0 +1,49 @@
+package chanwriter
+
+import (
+	"testing"
+
+	"github.com/trickstertwo/xlog"
+)
+
+func TestChanWriter_DeliversMergedEntries(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan xlog.Entry, 4)
+	logger, err := xlog.NewBuilder().WithAdapter(New(ch, DropNewest)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.With(xlog.Str("stage", "ingest")).Info().Int("n", 3).Msg("batch")
+
+	e := <-ch
+	if e.Level != xlog.LevelInfo || e.Message != "batch" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	if len(e.Fields) != 2 || e.Fields[0].K != "stage" || e.Fields[0].Str != "ingest" || e.Fields[1].K != "n" || e.Fields[1].Int64 != 3 {
+		t.Fatalf("unexpected fields: %+v", e.Fields)
+	}
+}
+
+func TestChanWriter_CountsDropsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan xlog.Entry, 1)
+	a := New(ch, DropNewest)
+	logger, err := xlog.NewBuilder().WithAdapter(a).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(xlog.Str("k", "v"))
+
+	logger.Info().Msg("kept")
+	logger.Info().Msg("dropped")
+	child.Info().Msg("dropped too")
+
+	if got := a.Dropped(); got != 2 {
+		t.Fatalf("Dropped() = %d, want 2 (shared with children)", got)
+	}
+	if e := <-ch; e.Message != "kept" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+}
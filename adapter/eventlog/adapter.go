@@ -0,0 +1,109 @@
+// Package eventlog provides an xlog.Adapter that writes to the Windows Event
+// Log. On other platforms New returns ErrUnsupported.
+package eventlog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// ErrUnsupported is returned by New on platforms without the Windows Event Log.
+var ErrUnsupported = errors.New("eventlog: Windows Event Log is only available on windows")
+
+// EventID is the event identifier reported for every entry.
+const EventID uint32 = 1
+
+// writer is the subset of *eventlog.Log the adapter needs; tests substitute a mock.
+type writer interface {
+	Info(eid uint32, msg string) error
+	Warning(eid uint32, msg string) error
+	Error(eid uint32, msg string) error
+	Close() error
+}
+
+// Adapter maps xlog levels to event types (Trace/Debug/Info -> Information,
+// Warn -> Warning, Error/Fatal -> Error) and renders the message followed by
+// "key=value" pairs, bound fields first, as the event string. The timestamp
+// is recorded by the Event Log itself.
+type Adapter struct {
+	w     writer
+	bound []xlog.Field
+}
+
+func newAdapter(w writer) *Adapter { return &Adapter{w: w} }
+
+// With returns a child adapter whose events include fs after the parent's
+// bound fields. Children share the event source.
+func (a *Adapter) With(fs []xlog.Field) xlog.Adapter {
+	child := *a
+	if len(fs) > 0 {
+		child.bound = append(append(make([]xlog.Field, 0, len(a.bound)+len(fs)), a.bound...), fs...)
+	}
+	return &child
+}
+
+// Log reports a single event. Write errors are dropped: there is nowhere
+// left to report them.
+func (a *Adapter) Log(level xlog.Level, msg string, _ time.Time, fields []xlog.Field) {
+	s := format(msg, a.bound, fields)
+	switch {
+	case level >= xlog.LevelError:
+		_ = a.w.Error(EventID, s)
+	case level >= xlog.LevelWarn:
+		_ = a.w.Warning(EventID, s)
+	default:
+		_ = a.w.Info(EventID, s)
+	}
+}
+
+// Close releases the event source handle.
+func (a *Adapter) Close() error { return a.w.Close() }
+
+func format(msg string, bound, fields []xlog.Field) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, fs := range [2][]xlog.Field{bound, fields} {
+		for i := range fs {
+			b.WriteByte(' ')
+			b.WriteString(fs[i].K)
+			b.WriteByte('=')
+			b.WriteString(value(&fs[i]))
+		}
+	}
+	return b.String()
+}
+
+func value(f *xlog.Field) string {
+	switch f.Kind {
+	case xlog.KindString:
+		return strconv.Quote(f.Str)
+	case xlog.KindInt64:
+		return strconv.FormatInt(f.Int64, 10)
+	case xlog.KindUint64:
+		return strconv.FormatUint(f.Uint64, 10)
+	case xlog.KindFloat64:
+		return strconv.FormatFloat(f.Float64, 'g', -1, 64)
+	case xlog.KindFloat32:
+		return strconv.FormatFloat(f.Float64, 'g', -1, 32)
+	case xlog.KindBool:
+		return strconv.FormatBool(f.Bool)
+	case xlog.KindDuration:
+		return f.Dur.String()
+	case xlog.KindTime:
+		return f.Time.UTC().Format(time.RFC3339Nano)
+	case xlog.KindError:
+		if f.Err == nil {
+			return "<nil>"
+		}
+		return strconv.Quote(f.Err.Error())
+	case xlog.KindBytes:
+		return strconv.Quote(string(f.Bytes))
+	default:
+		return strconv.Quote(fmt.Sprint(f.Any))
+	}
+}
@@ -0,0 +1,78 @@
+package eventlog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/trickstertwo/xlog"
+)
+
+type record struct {
+	typ string
+	msg string
+}
+
+type mockWriter struct {
+	recs   []record
+	closed bool
+}
+
+func (m *mockWriter) Info(_ uint32, msg string) error {
+	m.recs = append(m.recs, record{"info", msg})
+	return nil
+}
+
+func (m *mockWriter) Warning(_ uint32, msg string) error {
+	m.recs = append(m.recs, record{"warning", msg})
+	return nil
+}
+
+func (m *mockWriter) Error(_ uint32, msg string) error {
+	m.recs = append(m.recs, record{"error", msg})
+	return nil
+}
+
+func (m *mockWriter) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestEventLogAdapter_EventTypePerLevel(t *testing.T) {
+	mw := &mockWriter{}
+	logger, err := xlog.NewBuilder().
+		WithAdapter(newAdapter(mw)).
+		WithMinLevel(xlog.LevelTrace).
+		WithFields(xlog.Str("svc", "billing")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Trace().Msg("t")
+	logger.Debug().Msg("d")
+	logger.Info().Int("n", 3).Msg("i")
+	logger.Warn().Msg("w")
+	logger.Error().Err(errors.New("boom")).Msg("e")
+	logger.Fatal().Msg("f")
+	logger.Close()
+
+	want := []record{
+		{"info", `t svc="billing"`},
+		{"info", `d svc="billing"`},
+		{"info", `i svc="billing" n=3`},
+		{"warning", `w svc="billing"`},
+		{"error", `e svc="billing" error="boom"`},
+		{"error", `f svc="billing"`},
+	}
+	if len(mw.recs) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(mw.recs), len(want), mw.recs)
+	}
+	for i := range want {
+		if mw.recs[i] != want[i] {
+			t.Fatalf("event %d = %+v, want %+v", i, mw.recs[i], want[i])
+		}
+	}
+	if !mw.closed {
+		t.Fatal("Logger.Close did not close the event source")
+	}
+}
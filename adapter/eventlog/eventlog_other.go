@@ -0,0 +1,6 @@
+//go:build !windows
+
+package eventlog
+
+// New always returns ErrUnsupported outside Windows.
+func New(source string) (*Adapter, error) { return nil, ErrUnsupported }
@@ -0,0 +1,18 @@
+//go:build windows
+
+package eventlog
+
+import "golang.org/x/sys/windows/svc/eventlog"
+
+// New opens the event source, registering it (EventCreate.exe message file)
+// first when possible. Registration needs administrator rights and fails if
+// the source already exists; either way opening still proceeds, since an
+// already-registered source is the common case in production.
+func New(source string) (*Adapter, error) {
+	_ = eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return newAdapter(l), nil
+}
@@ -0,0 +1,10 @@
+module github.com/trickstertwo/xlog/adapter/eventlog
+
+go 1.25
+
+require (
+	github.com/trickstertwo/xlog v0.0.4
+	golang.org/x/sys v0.12.0
+)
+
+require github.com/trickstertwo/xclock v0.0.7 // indirect
@@ -0,0 +1,183 @@
+// Package gelf provides an xlog.Adapter that writes Graylog Extended Log
+// Format (GELF 1.1) JSON messages.
+package gelf
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// Adapter writes one GELF 1.1 JSON object per entry, followed by a newline,
+// with a single Write call (so a UDP writer sends one datagram per entry).
+// The message becomes short_message and every field becomes an additional
+// field prefixed with "_". GELF does not allow nested values, so booleans,
+// durations, times, errors and composite Any values are sent as strings.
+//
+// Reserved names: a field "full_message" fills the GELF full_message, and a
+// field "id" is sent as "__id" because "_id" is forbidden by the spec.
+type Adapter struct {
+	out   *output // shared with children so lines never interleave
+	pre   []byte  // '{"version":"1.1","host":...' prefix, computed once
+	bound []byte  // pre-encoded bound fields, each starting with ','
+}
+
+// New returns an adapter writing to w. The host is taken from os.Hostname.
+func New(w io.Writer) *Adapter {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	pre := append([]byte(`{"version":"1.1","host":`), quote(host)...)
+	return &Adapter{out: &output{w: w}, pre: pre}
+}
+
+// With returns a child adapter with fs encoded once and replayed on every entry.
+func (a *Adapter) With(fs []xlog.Field) xlog.Adapter {
+	child := *a
+	if len(fs) > 0 {
+		b := append([]byte(nil), a.bound...)
+		for i := range fs {
+			b = appendField(b, &fs[i])
+		}
+		child.bound = b
+	}
+	return &child
+}
+
+// Log writes a single entry, dropping write errors; use LogE to observe them
+// (xlog.FallbackAdapter does).
+func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.Field) {
+	_ = a.LogE(level, msg, at, fields)
+}
+
+// LogE writes a single entry and returns the writer's error.
+func (a *Adapter) LogE(level xlog.Level, msg string, at time.Time, fields []xlog.Field) error {
+	b := make([]byte, 0, len(a.pre)+len(a.bound)+128+len(msg))
+	b = append(b, a.pre...)
+	b = append(b, `,"short_message":`...)
+	b = append(b, quote(msg)...)
+	b = append(b, `,"timestamp":`...)
+	b = strconv.AppendFloat(b, float64(at.UnixMicro())/1e6, 'f', 6, 64)
+	b = append(b, `,"level":`...)
+	b = strconv.AppendInt(b, int64(syslogLevel(level)), 10)
+	b = append(b, a.bound...)
+	for i := range fields {
+		b = appendField(b, &fields[i])
+	}
+	b = append(b, '}', '\n')
+
+	a.out.mu.Lock()
+	defer a.out.mu.Unlock()
+	_, err := a.out.w.Write(b)
+	return err
+}
+
+// output is the writer shared by an adapter and its children.
+type output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetWriter redirects this adapter and every adapter derived from the same New
+// call to w. Entries being written finish on the previous writer.
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.out.mu.Lock()
+	a.out.w = w
+	a.out.mu.Unlock()
+}
+
+// syslogLevel maps xlog levels to syslog severities as used by GELF.
+func syslogLevel(l xlog.Level) int {
+	switch {
+	case l >= xlog.LevelFatal:
+		return 2 // critical
+	case l >= xlog.LevelError:
+		return 3 // error
+	case l >= xlog.LevelWarn:
+		return 4 // warning
+	case l >= xlog.LevelInfo:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
+
+func fieldKey(k string) string {
+	switch k {
+	case "full_message":
+		return k
+	case "id":
+		return "__id"
+	default:
+		return "_" + k
+	}
+}
+
+func appendField(b []byte, f *xlog.Field) []byte {
+	if f.Kind == xlog.KindError && f.Err == nil {
+		return b
+	}
+	b = append(b, ',')
+	b = append(b, quote(fieldKey(f.K))...)
+	b = append(b, ':')
+	switch f.Kind {
+	case xlog.KindString:
+		return append(b, quote(f.Str)...)
+	case xlog.KindInt64:
+		return strconv.AppendInt(b, f.Int64, 10)
+	case xlog.KindUint64:
+		return strconv.AppendUint(b, f.Uint64, 10)
+	case xlog.KindFloat64:
+		return appendFloat(b, f.Float64, 64)
+	case xlog.KindFloat32:
+		return appendFloat(b, f.Float64, 32)
+	case xlog.KindBool:
+		return append(b, quote(strconv.FormatBool(f.Bool))...)
+	case xlog.KindDuration:
+		return append(b, quote(f.Dur.String())...)
+	case xlog.KindTime:
+		return append(b, quote(f.Time.UTC().Format(time.RFC3339Nano))...)
+	case xlog.KindError:
+		return append(b, quote(f.Err.Error())...)
+	case xlog.KindBytes:
+		return append(b, quote(string(f.Bytes))...)
+	default:
+		return append(b, anyValue(f.Any)...)
+	}
+}
+
+// appendFloat writes v as a JSON number, or as a quoted string for NaN and
+// ±Inf, which JSON cannot represent.
+func appendFloat(b []byte, v float64, bits int) []byte {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		b = append(b, '"')
+		b = strconv.AppendFloat(b, v, 'g', -1, bits)
+		return append(b, '"')
+	}
+	return strconv.AppendFloat(b, v, 'g', -1, bits)
+}
+
+// anyValue encodes v as a JSON string or number; objects, arrays, booleans
+// and null are wrapped in a string since GELF values must be flat.
+func anyValue(v any) []byte {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return quote(err.Error())
+	}
+	if len(raw) > 0 && (raw[0] == '"' || raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9')) {
+		return raw
+	}
+	return quote(string(raw))
+}
+
+func quote(s string) []byte {
+	b, _ := json.Marshal(s) // strings always marshal
+	return b
+}
@@ -0,0 +1,138 @@
+package gelf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+func TestGELF_RequiredKeysAndCustomFields(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().
+		WithAdapter(New(&buf)).
+		WithFields(xlog.Str("svc", "api")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 250_000_000, time.UTC)
+	logger.LogAt(xlog.LevelWarn, "disk low",
+		xlog.Int64("free_mb", 512),
+		xlog.Bool("critical", false),
+		xlog.Str("id", "abc"),
+		xlog.Str("full_message", "details"),
+		xlog.Err("error", errors.New("ENOSPC")),
+		xlog.Any("tags", []string{"a", "b"}),
+		xlog.Time("at", at),
+	)
+
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal: %v; line=%s", err, buf.String())
+	}
+	host, _ := os.Hostname()
+	want := map[string]any{
+		"version":       "1.1",
+		"host":          host,
+		"short_message": "disk low",
+		"level":         float64(4),
+		"full_message":  "details",
+		"_svc":          "api",
+		"_free_mb":      float64(512),
+		"_critical":     "false",
+		"__id":          "abc",
+		"_error":        "ENOSPC",
+		"_tags":         `["a","b"]`,
+		"_at":           "2024-01-02T03:04:05.25Z",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Fatalf("%s = %#v, want %#v (line=%s)", k, m[k], v, buf.String())
+		}
+	}
+	if _, ok := m["timestamp"].(float64); !ok {
+		t.Fatalf("timestamp missing or not a number: %#v", m["timestamp"])
+	}
+	if len(m) != len(want)+1 {
+		t.Fatalf("unexpected keys in %s", buf.String())
+	}
+}
+
+func TestGELF_TimestampAndLevels(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	a := New(&buf)
+	at := time.Unix(1700000000, 123456000)
+	a.Log(xlog.LevelError, "x", at, nil)
+
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal: %v", err)
+	}
+	if m["timestamp"] != 1700000000.123456 {
+		t.Fatalf("timestamp = %v", m["timestamp"])
+	}
+	for l, want := range map[xlog.Level]int{
+		xlog.LevelTrace: 7, xlog.LevelDebug: 7, xlog.LevelInfo: 6,
+		xlog.LevelWarn: 4, xlog.LevelError: 3, xlog.LevelFatal: 2,
+	} {
+		if got := syslogLevel(l); got != want {
+			t.Fatalf("syslogLevel(%v) = %d, want %d", l, got, want)
+		}
+	}
+}
+
+func TestGELF_NonFiniteFloatsStayValidJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	a := New(&buf)
+	a.Log(xlog.LevelInfo, "x", time.Unix(0, 0), []xlog.Field{
+		xlog.Float64("nan", math.NaN()),
+		xlog.Float64("pos", math.Inf(1)),
+		xlog.Float32("neg", float32(math.Inf(-1))),
+		xlog.Float64("ok", 1.5),
+	})
+
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal %q: %v", buf.String(), err)
+	}
+	if m["_nan"] != "NaN" || m["_pos"] != "+Inf" || m["_neg"] != "-Inf" || m["_ok"] != 1.5 {
+		t.Fatalf("unexpected floats: %v", m)
+	}
+}
+
+func TestGELF_SetWriterRedirectsChildren(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(&first)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(xlog.Str("svc", "api"))
+	logger.Info().Msg("before")
+
+	if !child.SetWriter(&second) {
+		t.Fatal("SetWriter reported the gelf adapter as unsupported")
+	}
+	logger.Info().Msg("after")
+	child.Info().Msg("child after")
+
+	if n := bytes.Count(first.Bytes(), []byte("\n")); n != 1 {
+		t.Fatalf("first writer got %d lines, want 1", n)
+	}
+	if n := bytes.Count(second.Bytes(), []byte("\n")); n != 2 {
+		t.Fatalf("second writer got %d lines, want 2: %s", n, second.String())
+	}
+}
@@ -0,0 +1,403 @@
+// Package msgpack provides an xlog.Adapter that writes entries as MessagePack
+// maps, a compact binary alternative to JSON lines.
+package msgpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// Adapter writes one MessagePack map per entry with a single Write call:
+//
+//	{"ts": <timestamp ext>, "level": "info", "msg": "...", "fields": {...}}
+//
+// MessagePack is self-delimiting, so entries are simply concatenated. "ts"
+// uses the standard timestamp extension (type -1), durations are int64
+// nanoseconds, byte slices are bin, errors are their message, and nil errors
+// are omitted. Common kinds are encoded by hand; only KindAny values go
+// through reflection (and encoding/json for structs, honoring json tags).
+// Decimal values a float64 cannot represent exactly are kept as strings.
+//
+// Map keys are always strings: KindAny maps with int (or other) keys are
+// written with their fmt form, sorted as strings, as encoding/json does, so
+// every "fields" value decodes into the same map[string]any shape. Unlike the
+// JSON adapters, "fields" never repeats a key: when bound and event fields
+// share one, the event field wins, and among fields of one kind the last wins.
+type Adapter struct {
+	out   *output  // shared with children so entries never interleave
+	bound []byte   // pre-encoded bound key/value pairs
+	keys  []string // key of each pair in bound
+	offs  []int    // offset of each pair in bound
+}
+
+// New returns an adapter writing to w.
+func New(w io.Writer) *Adapter {
+	return &Adapter{out: &output{w: w}}
+}
+
+// With returns a child adapter with fs encoded once and replayed on every entry.
+// Bound pairs whose key fs sets again are dropped.
+func (a *Adapter) With(fs []xlog.Field) xlog.Adapter {
+	child := *a
+	if len(fs) == 0 {
+		return &child
+	}
+	child.bound, child.keys, child.offs = nil, nil, nil
+	for i, k := range a.keys {
+		if !shadowed(k, fs) {
+			child.offs = append(child.offs, len(child.bound))
+			child.keys = append(child.keys, k)
+			child.bound = append(child.bound, a.pair(i)...)
+		}
+	}
+	for i := range fs {
+		if skip(&fs[i]) || shadowed(fs[i].K, fs[i+1:]) {
+			continue
+		}
+		child.offs = append(child.offs, len(child.bound))
+		child.keys = append(child.keys, fs[i].K)
+		child.bound = appendField(child.bound, &fs[i])
+	}
+	return &child
+}
+
+// pair returns the encoded key/value pair i of a.bound.
+func (a *Adapter) pair(i int) []byte {
+	if i+1 < len(a.offs) {
+		return a.bound[a.offs[i]:a.offs[i+1]]
+	}
+	return a.bound[a.offs[i]:]
+}
+
+// shadowed reports whether fs sets key k with a field that is not skipped.
+func shadowed(k string, fs []xlog.Field) bool {
+	for i := range fs {
+		if fs[i].K == k && !skip(&fs[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Log writes a single entry, dropping write errors; use LogE to observe them
+// (xlog.FallbackAdapter does).
+func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.Field) {
+	_ = a.LogE(level, msg, at, fields)
+}
+
+// LogE writes a single entry and returns the writer's error.
+func (a *Adapter) LogE(level xlog.Level, msg string, at time.Time, fields []xlog.Field) error {
+	n := 0
+	for _, k := range a.keys {
+		if !shadowed(k, fields) {
+			n++
+		}
+	}
+	for i := range fields {
+		if !skip(&fields[i]) && !shadowed(fields[i].K, fields[i+1:]) {
+			n++
+		}
+	}
+
+	b := make([]byte, 0, 64+len(msg)+len(a.bound)+16*len(fields))
+	b = append(b, 0x84) // fixmap, 4 entries
+	b = appendStr(b, "ts")
+	b = appendTime(b, at)
+	b = appendStr(b, "level")
+	b = appendStr(b, level.String())
+	b = appendStr(b, "msg")
+	b = appendStr(b, msg)
+	b = appendStr(b, "fields")
+	b = appendMapHeader(b, n)
+	if n == len(a.keys)+len(fields) {
+		// No key is repeated or skipped: the common case.
+		b = append(b, a.bound...)
+		for i := range fields {
+			b = appendField(b, &fields[i])
+		}
+	} else {
+		for i, k := range a.keys {
+			if !shadowed(k, fields) {
+				b = append(b, a.pair(i)...)
+			}
+		}
+		for i := range fields {
+			if !skip(&fields[i]) && !shadowed(fields[i].K, fields[i+1:]) {
+				b = appendField(b, &fields[i])
+			}
+		}
+	}
+
+	a.out.mu.Lock()
+	defer a.out.mu.Unlock()
+	_, err := a.out.w.Write(b)
+	return err
+}
+
+// output is the writer shared by an adapter and its children.
+type output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetWriter redirects this adapter and every adapter derived from the same New
+// call to w. Entries being written finish on the previous writer.
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.out.mu.Lock()
+	a.out.w = w
+	a.out.mu.Unlock()
+}
+
+func skip(f *xlog.Field) bool { return f.Kind == xlog.KindError && f.Err == nil }
+
+func appendField(b []byte, f *xlog.Field) []byte {
+	b = appendStr(b, f.K)
+	switch f.Kind {
+	case xlog.KindString:
+		return appendStr(b, f.Str)
+	case xlog.KindInt64:
+		return appendInt(b, f.Int64)
+	case xlog.KindUint64:
+		return appendUint(b, f.Uint64)
+	case xlog.KindFloat64:
+		return appendFloat64(b, f.Float64)
+	case xlog.KindFloat32:
+		return appendFloat32(b, float32(f.Float64))
+	case xlog.KindBool:
+		return appendBool(b, f.Bool)
+	case xlog.KindDuration:
+		return appendInt(b, int64(f.Dur))
+	case xlog.KindTime:
+		return appendTime(b, f.Time)
+	case xlog.KindError:
+		return appendStr(b, f.Err.Error())
+	case xlog.KindBytes:
+		return appendBin(b, f.Bytes)
+	default:
+		return appendAny(b, f.Any)
+	}
+}
+
+func appendMapHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xde, byte(n>>8), byte(n))
+	default:
+		return append(b, 0xdf, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func appendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xdc, byte(n>>8), byte(n))
+	default:
+		return append(b, 0xdd, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func appendStr(b []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xda, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, s...)
+}
+
+func appendBin(b []byte, p []byte) []byte {
+	n := len(p)
+	switch {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xc5, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xc6, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, p...)
+}
+
+func appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 0xc3)
+	}
+	return append(b, 0xc2)
+}
+
+// appendInt uses the smallest encoding that holds v.
+func appendInt(b []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return appendUint(b, uint64(v))
+	case v >= -32:
+		return append(b, byte(v)) // negative fixint
+	case v >= math.MinInt8:
+		return append(b, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return append(b, 0xd1, byte(v>>8), byte(v))
+	case v >= math.MinInt32:
+		return append(b, 0xd2, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(b, 0xd3, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+func appendUint(b []byte, v uint64) []byte {
+	switch {
+	case v < 128:
+		return append(b, byte(v)) // positive fixint
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return append(b, 0xcd, byte(v>>8), byte(v))
+	case v <= math.MaxUint32:
+		return append(b, 0xce, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(b, 0xcf, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+func appendFloat64(b []byte, v float64) []byte {
+	u := math.Float64bits(v)
+	return append(b, 0xcb, byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32),
+		byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+func appendFloat32(b []byte, v float32) []byte {
+	u := math.Float32bits(v)
+	return append(b, 0xca, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+// appendTime writes the 96-bit timestamp extension: ext8, len 12, type -1,
+// uint32 nanoseconds, int64 seconds.
+func appendTime(b []byte, t time.Time) []byte {
+	ns, s := uint32(t.Nanosecond()), t.Unix()
+	return append(b, 0xc7, 12, 0xff,
+		byte(ns>>24), byte(ns>>16), byte(ns>>8), byte(ns),
+		byte(s>>56), byte(s>>48), byte(s>>40), byte(s>>32),
+		byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
+
+// appendAny encodes composite KindAny values. Slices, arrays and maps are
+// walked reflectively, maps with string keys in sorted order; structs and
+// other types are re-read from their JSON form so json tags and MarshalJSON
+// apply.
+func appendAny(b []byte, v any) []byte {
+	switch x := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case string:
+		return appendStr(b, x)
+	case bool:
+		return appendBool(b, x)
+	case int:
+		return appendInt(b, int64(x))
+	case int64:
+		return appendInt(b, x)
+	case uint64:
+		return appendUint(b, x)
+	case float64:
+		return appendFloat64(b, x)
+	case float32:
+		return appendFloat32(b, x)
+	case []byte:
+		return appendBin(b, x)
+	case time.Time:
+		return appendTime(b, x)
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return appendInt(b, i)
+		}
+		// Keep numbers a float64 would round (xlog Decimal) as strings.
+		if f, err := x.Float64(); err == nil && strconv.FormatFloat(f, 'g', -1, 64) == x.String() {
+			return appendFloat64(b, f)
+		}
+		return appendStr(b, x.String())
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendInt(b, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUint(b, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return appendFloat64(b, rv.Float())
+	case reflect.String:
+		return appendStr(b, rv.String())
+	case reflect.Bool:
+		return appendBool(b, rv.Bool())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return append(b, 0xc0)
+		}
+		b = appendArrayHeader(b, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			b = appendAny(b, rv.Index(i).Interface())
+		}
+		return b
+	case reflect.Map:
+		if rv.IsNil() {
+			return append(b, 0xc0)
+		}
+		// Keys become strings, like encoding/json, and are sorted so equal
+		// maps encode to equal bytes (StrMap promises sorted keys).
+		type pair struct {
+			k string
+			v reflect.Value
+		}
+		pairs := make([]pair, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, pair{fmt.Sprint(iter.Key().Interface()), iter.Value()})
+		}
+		slices.SortFunc(pairs, func(x, y pair) int { return strings.Compare(x.k, y.k) })
+		b = appendMapHeader(b, len(pairs))
+		for _, p := range pairs {
+			b = appendStr(b, p.k)
+			b = appendAny(b, p.v.Interface())
+		}
+		return b
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return append(b, 0xc0)
+		}
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return appendStr(b, err.Error())
+	}
+	// UseNumber keeps integers integral instead of widening them to float64.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return appendStr(b, string(raw))
+	}
+	return appendAny(b, generic)
+}
@@ -0,0 +1,336 @@
+package msgpack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	msgpackv5 "github.com/vmihailenco/msgpack/v5"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// decodeAll reads concatenated entries with an independent MessagePack
+// implementation. Loose decoding widens ints to int64 or uint64 (by wire
+// signedness), floats to float64 and bin to string; ints are then folded to
+// int64 where they fit, so expectations need not track the encoder's width
+// choices.
+func decodeAll(t *testing.T, b []byte) []map[string]any {
+	t.Helper()
+	dec := msgpackv5.NewDecoder(bytes.NewReader(b))
+	dec.UseLooseInterfaceDecoding(true)
+	var entries []map[string]any
+	for {
+		var e map[string]any
+		if err := dec.Decode(&e); err == io.EOF {
+			return entries
+		} else if err != nil {
+			t.Fatalf("decode entry %d: %v", len(entries), err)
+		}
+		entries = append(entries, foldInts(e).(map[string]any))
+	}
+}
+
+func foldInts(v any) any {
+	switch x := v.(type) {
+	case uint64:
+		if x <= math.MaxInt64 {
+			return int64(x)
+		}
+	case map[string]any:
+		for k, e := range x {
+			x[k] = foldInts(e)
+		}
+	case []any:
+		for i, e := range x {
+			x[i] = foldInts(e)
+		}
+	}
+	return v
+}
+
+func TestMsgPack_EntryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().
+		WithAdapter(New(&buf)).
+		WithFields(xlog.Str("svc", "api")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	type point struct {
+		X int `json:"x"`
+	}
+	logger.LogAt(xlog.LevelWarn, "disk low",
+		xlog.Int64("free", 512),
+		xlog.Int64("delta", -40000),
+		xlog.Uint64("big", math.MaxUint64),
+		xlog.Float64("ratio", 0.25),
+		xlog.Float32("f32", 0.1),
+		xlog.Bool("critical", true),
+		xlog.Dur("took", 3*time.Millisecond),
+		xlog.Time("at", at),
+		xlog.Err("error", errors.New("ENOSPC")),
+		xlog.Err("none", nil),
+		xlog.Bytes("raw", []byte{0, 1}),
+		xlog.Any("tags", []string{"a", "b"}),
+		xlog.Any("pt", point{X: 3}),
+	)
+
+	logger.Info().Decimal("exact", "0.5").Decimal("precise", "1234.5678901234567890").Msg("money")
+
+	entries := decodeAll(t, buf.Bytes())
+	if len(entries) != 2 {
+		t.Fatalf("decoded %d entries, want 2", len(entries))
+	}
+	entry := entries[0]
+	if len(entry) != 4 || entry["level"] != "warn" || entry["msg"] != "disk low" {
+		t.Fatalf("entry = %v", entry)
+	}
+	if ts, ok := entry["ts"].(time.Time); !ok || ts.IsZero() {
+		t.Fatalf("ts = %#v", entry["ts"])
+	}
+	fields := entry["fields"].(map[string]any)
+	if got, ok := fields["at"].(time.Time); !ok || !got.Equal(at) {
+		t.Fatalf("at = %#v, want %v", fields["at"], at)
+	}
+	delete(fields, "at")
+	want := map[string]any{
+		"svc":      "api",
+		"free":     int64(512),
+		"delta":    int64(-40000),
+		"big":      uint64(math.MaxUint64),
+		"ratio":    0.25,
+		"f32":      float64(float32(0.1)),
+		"critical": true,
+		"took":     int64(3 * time.Millisecond),
+		"error":    "ENOSPC",
+		"raw":      "\x00\x01",
+		"tags":     []any{"a", "b"},
+		"pt":       map[string]any{"x": int64(3)},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("fields =\n%#v\nwant\n%#v", fields, want)
+	}
+
+	want = map[string]any{"svc": "api", "exact": 0.5, "precise": "1234.5678901234567890"}
+	if fields := entries[1]["fields"]; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("decimal fields = %#v, want %#v", fields, want)
+	}
+}
+
+func TestMsgPack_ConcatenatedEntries(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(&buf)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		logger.Info().Int("i", i).Msg("tick")
+	}
+
+	entries := decodeAll(t, buf.Bytes())
+	if len(entries) != 3 {
+		t.Fatalf("decoded %d entries, want 3", len(entries))
+	}
+	for i, e := range entries {
+		if n := e["fields"].(map[string]any)["i"]; n != int64(i) {
+			t.Fatalf("entry %d: i = %v", i, n)
+		}
+	}
+}
+
+func TestMsgPack_SetWriter(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(&first)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Msg("before")
+	n := first.Len()
+	if !logger.SetWriter(&second) {
+		t.Fatal("SetWriter reported the msgpack adapter as unsupported")
+	}
+	logger.Info().Msg("after")
+
+	if first.Len() != n || second.Len() == 0 {
+		t.Fatalf("entry not redirected: first %d bytes (was %d), second %d bytes", first.Len(), n, second.Len())
+	}
+}
+
+// TestMsgPack_GoldenEncodings checks the encoders against byte sequences taken
+// from the MessagePack specification.
+func TestMsgPack_GoldenEncodings(t *testing.T) {
+	t.Parallel()
+
+	str := func(n int) string { return string(bytes.Repeat([]byte("a"), n)) }
+	cat := func(parts ...[]byte) []byte { return bytes.Join(parts, nil) }
+	for _, tc := range []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"nil", appendAny(nil, nil), []byte{0xc0}},
+		{"false", appendBool(nil, false), []byte{0xc2}},
+		{"true", appendBool(nil, true), []byte{0xc3}},
+		{"positive fixint", appendInt(nil, 127), []byte{0x7f}},
+		{"negative fixint", appendInt(nil, -32), []byte{0xe0}},
+		{"int8", appendInt(nil, -33), []byte{0xd0, 0xdf}},
+		{"int16", appendInt(nil, -129), []byte{0xd1, 0xff, 0x7f}},
+		{"int32", appendInt(nil, math.MinInt32), []byte{0xd2, 0x80, 0x00, 0x00, 0x00}},
+		{"int64", appendInt(nil, math.MinInt64), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{"uint8", appendUint(nil, 128), []byte{0xcc, 0x80}},
+		{"uint16", appendUint(nil, 256), []byte{0xcd, 0x01, 0x00}},
+		{"uint32", appendUint(nil, 1<<16), []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		{"uint64", appendUint(nil, 1<<32), []byte{0xcf, 0, 0, 0, 1, 0, 0, 0, 0}},
+		{"float32", appendFloat32(nil, 1.5), []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}},
+		{"float64", appendFloat64(nil, 1.5), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"fixstr", appendStr(nil, "abc"), []byte{0xa3, 'a', 'b', 'c'}},
+		{"str8", appendStr(nil, str(32)), cat([]byte{0xd9, 32}, []byte(str(32)))},
+		{"str16", appendStr(nil, str(256)), cat([]byte{0xda, 0x01, 0x00}, []byte(str(256)))},
+		{"str32", appendStr(nil, str(1<<16)), cat([]byte{0xdb, 0x00, 0x01, 0x00, 0x00}, []byte(str(1<<16)))},
+		{"bin8", appendBin(nil, []byte{1, 2}), []byte{0xc4, 0x02, 0x01, 0x02}},
+		{"bin16", appendBin(nil, make([]byte, 256)), cat([]byte{0xc5, 0x01, 0x00}, make([]byte, 256))},
+		{"fixarray", appendAny(nil, []int{1, 2}), []byte{0x92, 0x01, 0x02}},
+		{"array16", appendAny(nil, make([]int, 16)), cat([]byte{0xdc, 0x00, 0x10}, make([]byte, 16))},
+		{"fixmap sorted", appendAny(nil, map[string]string{"b": "2", "a": "1"}),
+			[]byte{0x82, 0xa1, 'a', 0xa1, '1', 0xa1, 'b', 0xa1, '2'}},
+		{"map16 header", appendMapHeader(nil, 16), []byte{0xde, 0x00, 0x10}},
+		{"timestamp96", appendTime(nil, time.Unix(1, 2)),
+			[]byte{0xc7, 12, 0xff, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1}},
+	} {
+		if !bytes.Equal(tc.got, tc.want) {
+			t.Errorf("%s: got % x, want % x", tc.name, head(tc.got), head(tc.want))
+		}
+	}
+}
+
+// head trims long encodings for readable failure messages.
+func head(b []byte) []byte {
+	if len(b) > 16 {
+		return b[:16]
+	}
+	return b
+}
+
+func TestMsgPack_StrMapIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	m := make(map[string]string, 20)
+	for i := 0; i < 20; i++ {
+		m[fmt.Sprintf("k%02d", i)] = "v"
+	}
+	var first []byte
+	for i := 0; i < 10; i++ {
+		var buf bytes.Buffer
+		logger, err := xlog.NewBuilder().WithAdapter(New(&buf)).Build()
+		if err != nil {
+			t.Fatalf("build logger: %v", err)
+		}
+		logger.Info().StrMap("m", m).Msg("x")
+		b := buf.Bytes()
+		// Drop the timestamp, which differs between entries.
+		if i := bytes.Index(b, []byte("level")); i >= 0 {
+			b = b[i:]
+		}
+		if first == nil {
+			first = b
+		} else if !bytes.Equal(b, first) {
+			t.Fatalf("StrMap encoding differs between runs")
+		}
+	}
+	i := bytes.Index(first, []byte{0xa1, 'm', 0xde, 0x00, 0x14})
+	if i < 0 {
+		t.Fatalf("map16 header for 20 keys not found: % x", first)
+	}
+	if j := bytes.Index(first, []byte("k00")); j < i || bytes.Index(first, []byte("k19")) < j {
+		t.Fatalf("keys not in sorted order")
+	}
+}
+
+func TestMsgPack_NonStringMapKeys(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(&buf)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().
+		Any("ports", map[int]string{10: "ten", 2: "two", 1: "one"}).
+		Any("flags", map[bool]int{true: 1}).
+		Msg("keys")
+
+	// Keys are strings sorted as strings, as encoding/json would write them.
+	if !bytes.Contains(buf.Bytes(), []byte{0x83, 0xa1, '1', 0xa3, 'o', 'n', 'e', 0xa2, '1', '0', 0xa3, 't', 'e', 'n', 0xa1, '2'}) {
+		t.Fatalf("ports not encoded with sorted string keys: % x", buf.Bytes())
+	}
+	fields := decodeAll(t, buf.Bytes())[0]["fields"].(map[string]any)
+	want := map[string]any{
+		"ports": map[string]any{"1": "one", "10": "ten", "2": "two"},
+		"flags": map[string]any{"true": int64(1)},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("fields = %#v, want %#v", fields, want)
+	}
+}
+
+func TestMsgPack_DuplicateKeysLastWins(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().
+		WithAdapter(New(&buf)).
+		WithFields(xlog.Str("svc", "api"), xlog.Str("region", "eu")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(xlog.Str("region", "us"), xlog.Int64("shard", 1))
+	child.Info().Str("svc", "worker").Int("n", 1).Int("n", 2).Err(nil).Msg("dup")
+	child.Info().Msg("plain")
+
+	entries := decodeAll(t, buf.Bytes())
+	want := []map[string]any{
+		{"region": "us", "shard": int64(1), "svc": "worker", "n": int64(2)},
+		{"svc": "api", "region": "us", "shard": int64(1)},
+	}
+	for i, e := range entries {
+		if got := e["fields"]; !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("entry %d fields = %#v, want %#v", i, got, want[i])
+		}
+	}
+
+	// Decoding into a map hides repeats, so check the wire: a decoder keeping
+	// the first duplicate would disagree with one keeping the last.
+	dec := msgpackv5.NewDecoder(bytes.NewReader(buf.Bytes()))
+	if n, err := dec.DecodeMapLen(); err != nil || n != 4 {
+		t.Fatalf("entry map len = %d, %v", n, err)
+	}
+	for i := 0; i < 3; i++ { // ts, level, msg
+		if err := dec.Skip(); err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Skip(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if k, err := dec.DecodeString(); err != nil || k != "fields" {
+		t.Fatalf("fourth key = %q, %v", k, err)
+	}
+	if n, err := dec.DecodeMapLen(); err != nil || n != len(want[0]) {
+		t.Fatalf("fields map len = %d, %v; want %d", n, err, len(want[0]))
+	}
+}
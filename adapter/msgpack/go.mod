@@ -0,0 +1,13 @@
+module github.com/trickstertwo/xlog/adapter/msgpack
+
+go 1.25
+
+require (
+	github.com/trickstertwo/xlog v0.0.4
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/trickstertwo/xclock v0.0.7 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+)
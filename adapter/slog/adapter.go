@@ -4,7 +4,9 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/trickstertwo/xlog"
@@ -24,13 +26,18 @@ import (
 //     to adjust backend filtering to match xlog's MinLevel. If no LevelVar is
 //     provided, SetMinLevel is a no-op (xlog filtering still applies).
 type Adapter struct {
-	l     *slog.Logger
-	lv    *slog.LevelVar // optional, enables SetMinLevel
-	tsKey string         // timestamp field key; default "ts"
+	l      *slog.Logger
+	lv     *slog.LevelVar // optional, enables SetMinLevel
+	w      io.Writer      // optional; flushed on Close when it supports it
+	tsKey  string         // timestamp field key; default "ts"
+	omitTS bool           // skip the injected timestamp (sink adds its own)
 }
 
 var bg = context.Background()
 
+// float32Value carries a float32 through slog without widening.
+type float32Value float32
+
 func toSlog(l xlog.Level) slog.Level { return slog.Level(l) }
 
 // New creates an adapter for the provided slog logger.
@@ -94,7 +101,9 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 	attrs := make([]slog.Attr, 0, 1+len(fields))
 
 	// Deterministic timestamp precision across handlers/encoders.
-	attrs = append(attrs, slog.String(a.tsKey, at.UTC().Format(time.RFC3339Nano)))
+	if !a.omitTS {
+		attrs = append(attrs, slog.String(a.tsKey, at.UTC().Format(time.RFC3339Nano)))
+	}
 
 	for i := range fields {
 		attrs = append(attrs, toAttr(&fields[i]))
@@ -103,6 +112,43 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 	a.l.LogAttrs(bg, sl, msg, attrs...)
 }
 
+// BorrowsFields reports that Log converts fields before returning and never
+// keeps the slice, letting xlog skip its defensive copy.
+func (a *Adapter) BorrowsFields() bool { return true }
+
+// WithMinLevel returns a child whose handler filters on its own LevelVar set to
+// l instead of the shared one, so xlog.Logger.WithLevel can log below it.
+// SetMinLevel on the child only changes the child's level.
+func (a *Adapter) WithMinLevel(l xlog.Level) xlog.Adapter {
+	lv := new(slog.LevelVar)
+	lv.Set(toSlog(l))
+	h := a.l.Handler()
+	if lh, ok := h.(*levelHandler); ok {
+		h = lh.Handler
+	}
+	child := *a
+	child.lv = lv
+	child.l = slog.New(&levelHandler{Handler: h, lv: lv})
+	return &child
+}
+
+// levelHandler replaces the wrapped handler's Enabled check with its own
+// LevelVar. The standard handlers do not filter again in Handle.
+type levelHandler struct {
+	slog.Handler
+	lv *slog.LevelVar
+}
+
+func (h *levelHandler) Enabled(_ context.Context, l slog.Level) bool { return l >= h.lv.Level() }
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), lv: h.lv}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), lv: h.lv}
+}
+
 // SetMinLevel updates the backend filter when a LevelVar was supplied.
 // If not provided, this is a no-op (xlog filtering still applies).
 func (a *Adapter) SetMinLevel(l xlog.Level) {
@@ -112,6 +158,20 @@ func (a *Adapter) SetMinLevel(l xlog.Level) {
 	a.lv.Set(toSlog(l))
 }
 
+// Close is best-effort: slog handlers expose no flush hook, so it flushes the
+// writer configured via Use (or the New*Logger helpers) when it exposes
+// Flush() error or Sync() error. The writer is never closed.
+func (a *Adapter) Close() error {
+	switch w := a.w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Sync() error }:
+		return w.Sync()
+	default:
+		return nil
+	}
+}
+
 func toAttr(f *xlog.Field) slog.Attr {
 	switch f.Kind {
 	case xlog.KindString:
@@ -122,6 +182,10 @@ func toAttr(f *xlog.Field) slog.Attr {
 		return slog.Uint64(f.K, f.Uint64)
 	case xlog.KindFloat64:
 		return slog.Float64(f.K, f.Float64)
+	case xlog.KindFloat32:
+		// slog.AnyValue widens float32; a named type keeps it, so the JSON
+		// handler marshals it with 32-bit precision.
+		return slog.Any(f.K, float32Value(f.Float64))
 	case xlog.KindBool:
 		return slog.Bool(f.K, f.Bool)
 	case xlog.KindDuration:
@@ -133,12 +197,43 @@ func toAttr(f *xlog.Field) slog.Attr {
 	case xlog.KindBytes:
 		return slog.Any(f.K, f.Bytes)
 	case xlog.KindAny:
+		if g, ok := mapGroup(f.K, f.Any); ok {
+			return g
+		}
 		return slog.Any(f.K, f.Any)
 	default:
 		return slog.Any(f.K, nil)
 	}
 }
 
+// mapGroup renders the typed maps produced by Event.StrMap/IntMap as a slog
+// group with sorted keys. Empty maps are left to slog.Any, since slog elides
+// empty groups and the JSON handler encodes an empty map as {}.
+func mapGroup(k string, v any) (slog.Attr, bool) {
+	switch m := v.(type) {
+	case map[string]string:
+		if len(m) == 0 {
+			return slog.Attr{}, false
+		}
+		attrs := make([]slog.Attr, 0, len(m))
+		for _, mk := range slices.Sorted(maps.Keys(m)) {
+			attrs = append(attrs, slog.String(mk, m[mk]))
+		}
+		return slog.Attr{Key: k, Value: slog.GroupValue(attrs...)}, true
+	case map[string]int64:
+		if len(m) == 0 {
+			return slog.Attr{}, false
+		}
+		attrs := make([]slog.Attr, 0, len(m))
+		for _, mk := range slices.Sorted(maps.Keys(m)) {
+			attrs = append(attrs, slog.Int64(mk, m[mk]))
+		}
+		return slog.Attr{Key: k, Value: slog.GroupValue(attrs...)}, true
+	default:
+		return slog.Attr{}, false
+	}
+}
+
 // NewJSONLogger builds an xlog.Logger wired to a slog JSON handler.
 // It uses a LevelVar so Adapter.SetMinLevel can adjust the backend level.
 func NewJSONLogger(w io.Writer, minLevel xlog.Level, opts *slog.HandlerOptions, observers ...xlog.Observer) (*xlog.Logger, error) {
@@ -155,6 +250,7 @@ func NewJSONLogger(w io.Writer, minLevel xlog.Level, opts *slog.HandlerOptions,
 	handler := slog.NewJSONHandler(w, opts)
 	sl := slog.New(handler)
 	adapter := NewWithLevelVar(sl, &lv)
+	adapter.w = w
 
 	b := xlog.NewBuilder().
 		WithAdapter(adapter).
@@ -181,6 +277,7 @@ func NewTextLogger(w io.Writer, minLevel xlog.Level, opts *slog.HandlerOptions,
 	handler := slog.NewTextHandler(w, opts)
 	sl := slog.New(handler)
 	adapter := NewWithLevelVar(sl, &lv)
+	adapter.w = w
 
 	b := xlog.NewBuilder().
 		WithAdapter(adapter).
@@ -1,9 +1,13 @@
 package slog
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -52,3 +56,173 @@ func TestSlogAdapter_JSONHandler_EmitsTSAndFields(t *testing.T) {
 	}
 	// Level and time are produced by slog; we don't assert them due to variability
 }
+
+func TestUse_OmitTimestamp(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Neither the configured key nor slog's own "time" attribute may appear.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, TimestampFieldName: "at", OmitTimestamp: true})
+	logger.Info().Str("k", "v").Msg("no ts")
+
+	if got, want := buf.String(), `{"level":"INFO","msg":"no ts","k":"v"}`+"\n"; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
+
+func TestUse_CloseFlushesWriter(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	logger := Use(Config{Writer: bw, MinLevel: xlog.LevelInfo})
+	logger.Info().Msg("buffered")
+	if buf.Len() != 0 {
+		t.Fatalf("expected output to be buffered before Close, got %q", buf.String())
+	}
+	logger.Close()
+	if !bytes.Contains(buf.Bytes(), []byte(`"msg":"buffered"`)) {
+		t.Fatalf("expected buffered entry after Close, got %q", buf.String())
+	}
+}
+
+func TestUse_IncludeHostPID(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	// slog pre-formats With attributes, so host and pid precede the
+	// per-record ts and event fields.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, IncludeHostPID: true})
+	logger.Info().Str("k", "v").Msg("boot")
+
+	want := fmt.Sprintf(`"msg":"boot","host":%q,"pid":%d,"ts":`, host, os.Getpid())
+	if !strings.Contains(buf.String(), want) {
+		t.Fatalf("line %s does not contain %s", buf.String(), want)
+	}
+}
+
+func TestUse_StrMapIntMapAsObjects(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Non-empty maps become slog groups, which the text handler flattens into
+	// dotted keys; empty ones fall back to slog.Any since groups would vanish.
+	var buf bytes.Buffer
+	dropTime := func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey || a.Key == "ts" {
+			return slog.Attr{}
+		}
+		return a
+	}
+	logger, err := NewTextLogger(&buf, xlog.LevelInfo, &slog.HandlerOptions{ReplaceAttr: dropTime})
+	if err != nil {
+		t.Fatalf("NewTextLogger: %v", err)
+	}
+	logger.Info().
+		StrMap("labels", map[string]string{"zone": "b", "app": "api"}).
+		IntMap("counts", map[string]int64{"ok": 2, "err": 1}).
+		Msg("maps")
+	logger.Info().StrMap("labels", nil).IntMap("counts", map[string]int64{}).Msg("empty")
+
+	want := "level=INFO msg=maps labels.app=api labels.zone=b counts.err=1 counts.ok=2\n" +
+		"level=INFO msg=empty labels=map[] counts=map[]\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestUse_Float32ShortestForm(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// float32Value survives slog.AnyValue, so both handlers format it at
+	// 32-bit precision.
+	var text bytes.Buffer
+	tl, err := NewTextLogger(&text, xlog.LevelInfo, nil)
+	if err != nil {
+		t.Fatalf("NewTextLogger: %v", err)
+	}
+	tl.Info().Float32("ratio", 0.1).Msg("f32")
+	if !strings.HasSuffix(text.String(), " ratio=0.1\n") {
+		t.Fatalf("text line = %q, want ratio=0.1", text.String())
+	}
+
+	var js bytes.Buffer
+	logger := Use(Config{Writer: &js, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	logger.Info().Float32("ratio", 0.1).Msg("f32")
+	if got, want := js.String(), `{"level":"INFO","msg":"f32","ratio":0.1}`+"\n"; got != want {
+		t.Fatalf("json line = %q, want %q", got, want)
+	}
+}
+
+func TestUse_SampleRate(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true, SampleRate: 0.5})
+	for i := 0; i < 1000; i++ {
+		logger.Info().Msg("tick")
+		logger.Error().Msg("tick")
+	}
+
+	// slog's JSON handler writes levels upper-case.
+	infos := bytes.Count(buf.Bytes(), []byte(`"level":"INFO"`))
+	errs := bytes.Count(buf.Bytes(), []byte(`"level":"ERROR"`))
+	if errs != 1000 {
+		t.Fatalf("errors must bypass sampling, got %d of 1000", errs)
+	}
+	if infos < 400 || infos > 600 {
+		t.Fatalf("got %d of 1000 info lines, want about half", infos)
+	}
+}
+
+func TestSlogAdapter_DecimalUnquoted(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().
+		WithAdapter(New(slog.New(slog.NewJSONHandler(&buf, nil)))).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Decimal("amount", "1234.5678901234567890").Msg("money")
+
+	if want := `"amount":1234.5678901234567890`; !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Fatalf("missing %s: %s", want, buf.String())
+	}
+}
+
+func TestUse_WithLevelChildLogsBelowBackendLevel(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	child := logger.With(xlog.Str("req", "r-1")).WithLevel(xlog.LevelDebug)
+
+	child.Debug().Msg("child-debug")
+	logger.Debug().Msg("parent-debug")
+	child.SetMinLevel(xlog.LevelError)
+	child.Info().Msg("child-info")
+	logger.Info().Msg("parent-info")
+
+	out := buf.String()
+	if !strings.Contains(out, "child-debug") || !strings.Contains(out, `"req":"r-1"`) {
+		t.Fatalf("child debug line missing: %s", out)
+	}
+	if strings.Contains(out, "parent-debug") || strings.Contains(out, "child-info") {
+		t.Fatalf("level leaked between parent and child: %s", out)
+	}
+	if !strings.Contains(out, "parent-info") {
+		t.Fatalf("child SetMinLevel changed the parent's backend level: %s", out)
+	}
+}
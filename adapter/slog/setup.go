@@ -25,6 +25,9 @@ type Config struct {
 	Format             Format     // JSON (default)
 	TimestampFieldName string     // default "ts" (aligns with xlog's authoritative timestamp)
 	Caller             bool       // sets AddSource=true when requested
+	OmitTimestamp      bool       // skip the ts field entirely (sink adds its own)
+	IncludeHostPID     bool       // bind host and pid fields to every entry
+	SampleRate         float64    // keep this fraction (0..1) of entries below error; 0 keeps all
 	_                  struct{}   // future-proofing
 }
 
@@ -68,13 +71,21 @@ func Use(cfg Config) *xlog.Logger {
 
 	// Wrap in adapter and bind xlog to the current process clock (xclock.Default()).
 	ad := NewWithTimestampKey(sl, &lv, cfg.TimestampFieldName)
+	ad.omitTS = cfg.OmitTimestamp
+	ad.w = w
 	ad.SetMinLevel(cfg.MinLevel)
 
-	logger, err := xlog.NewBuilder().
+	b := xlog.NewBuilder().
 		WithAdapter(ad).
 		WithMinLevel(cfg.MinLevel).
-		WithClock(xclock.Default()).
-		Build()
+		WithClock(xclock.Default())
+	if cfg.IncludeHostPID {
+		b = b.WithFields(xlog.HostPIDFields()...)
+	}
+	if cfg.SampleRate > 0 {
+		b = b.WithSampler(xlog.NewRateSampler(cfg.SampleRate))
+	}
+	logger, err := b.Build()
 	if err != nil {
 		panic(err)
 	}
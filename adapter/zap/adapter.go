@@ -1,6 +1,9 @@
 package zap
 
 import (
+	"encoding/json"
+	"maps"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -22,9 +25,10 @@ import (
 //     to adjust backend filtering to match xlog's MinLevel. If no AtomicLevel
 //     is provided, SetMinLevel is a no-op (xlog filtering still applies).
 type Adapter struct {
-	l     *zap.Logger
-	al    *zap.AtomicLevel // optional, enables SetMinLevel
-	tsKey string           // timestamp field key; default "ts"
+	l      *zap.Logger
+	al     *zap.AtomicLevel // optional, enables SetMinLevel
+	tsKey  string           // timestamp field key; default "ts"
+	omitTS bool             // skip the injected timestamp (sink adds its own)
 }
 
 // New creates an adapter for the provided zap logger.
@@ -83,7 +87,9 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 	zfs := make([]zap.Field, 0, 1+len(fields))
 
 	// Ensure RFC3339Nano precision regardless of encoder defaults.
-	zfs = append(zfs, zap.String(a.tsKey, at.UTC().Format(time.RFC3339Nano)))
+	if !a.omitTS {
+		zfs = append(zfs, zap.String(a.tsKey, at.UTC().Format(time.RFC3339Nano)))
+	}
 
 	// Convert event fields
 	for i := range fields {
@@ -93,6 +99,46 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 	ce.Write(zfs...)
 }
 
+// BorrowsFields reports that Log converts fields before returning and never
+// keeps the slice, letting xlog skip its defensive copy.
+func (a *Adapter) BorrowsFields() bool { return true }
+
+// WithMinLevel returns a child whose core filters on its own AtomicLevel set to
+// l instead of the shared one, so xlog.Logger.WithLevel can log below it.
+// SetMinLevel on the child only changes the child's level.
+func (a *Adapter) WithMinLevel(l xlog.Level) xlog.Adapter {
+	al := zap.NewAtomicLevelAt(toZapLevel(l))
+	child := *a
+	child.al = &al
+	child.l = a.l.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		if lc, ok := c.(*levelCore); ok {
+			c = lc.Core
+		}
+		return &levelCore{Core: c, al: al}
+	}))
+	return &child
+}
+
+// levelCore replaces the wrapped core's level check with its own AtomicLevel.
+// It relies on Write not filtering again, which holds for zapcore.NewCore.
+type levelCore struct {
+	zapcore.Core
+	al zap.AtomicLevel
+}
+
+func (c *levelCore) Enabled(l zapcore.Level) bool { return c.al.Enabled(l) }
+
+func (c *levelCore) With(fs []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fs), al: c.al}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
 // SetMinLevel updates the backend filter when an AtomicLevel was supplied.
 // If not provided, this is a no-op (xlog filtering still applies).
 func (a *Adapter) SetMinLevel(l xlog.Level) {
@@ -102,6 +148,13 @@ func (a *Adapter) SetMinLevel(l xlog.Level) {
 	a.al.SetLevel(toZapLevel(l))
 }
 
+// Close flushes buffered entries by syncing the zap logger. xlog.Logger.Close
+// calls it, so buffered cores (e.g. zapcore.BufferedWriteSyncer) are drained
+// on shutdown.
+func (a *Adapter) Close() error {
+	return a.l.Sync()
+}
+
 func toZapLevel(l xlog.Level) zapcore.Level {
 	switch {
 	case l <= xlog.LevelTrace:
@@ -138,6 +191,8 @@ func toZapField(f *xlog.Field) zap.Field {
 		return zap.Uint64(f.K, f.Uint64)
 	case xlog.KindFloat64:
 		return zap.Float64(f.K, f.Float64)
+	case xlog.KindFloat32:
+		return zap.Float32(f.K, float32(f.Float64))
 	case xlog.KindBool:
 		return zap.Bool(f.K, f.Bool)
 	case xlog.KindDuration:
@@ -155,8 +210,46 @@ func toZapField(f *xlog.Field) zap.Field {
 	case xlog.KindBytes:
 		return zap.ByteString(f.K, f.Bytes)
 	case xlog.KindAny:
+		switch v := f.Any.(type) {
+		case []time.Duration:
+			return zap.Durations(f.K, v) // honors EncodeDuration per element
+		case []time.Time:
+			return zap.Times(f.K, v) // honors EncodeTime per element
+		case []bool:
+			return zap.Bools(f.K, v)
+		case json.Number:
+			// zap.Any would pick zap.Stringer and quote it; the reflected
+			// encoder (encoding/json) writes the number verbatim.
+			return zap.Reflect(f.K, v)
+		}
+		if m, ok := mapMarshaler(f.Any); ok {
+			return zap.Object(f.K, m)
+		}
 		return zap.Any(f.K, f.Any)
 	default:
 		return zap.Skip()
 	}
 }
+
+// mapMarshaler encodes the typed maps produced by Event.StrMap/IntMap as zap
+// objects with sorted keys, avoiding zap.Any's reflection fallback.
+func mapMarshaler(v any) (zapcore.ObjectMarshaler, bool) {
+	switch m := v.(type) {
+	case map[string]string:
+		return zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				enc.AddString(k, m[k])
+			}
+			return nil
+		}), true
+	case map[string]int64:
+		return zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				enc.AddInt64(k, m[k])
+			}
+			return nil
+		}), true
+	default:
+		return nil, false
+	}
+}
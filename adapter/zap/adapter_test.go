@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -109,3 +112,263 @@ func TestZapAdapter_WithBoundFields(t *testing.T) {
 		t.Fatalf("bound + event fields missing: %v", m)
 	}
 }
+
+func TestUse_OmitTimestamp(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// A custom EncoderConfig asking for zap's own time key must not bring a
+	// timestamp back either.
+	var buf bytes.Buffer
+	logger := Use(Config{
+		Writer:   &buf,
+		MinLevel: xlog.LevelInfo,
+		EncoderConfig: zapcore.EncoderConfig{
+			TimeKey:     "time",
+			LevelKey:    "level",
+			MessageKey:  "msg",
+			EncodeLevel: zapcore.LowercaseLevelEncoder,
+			EncodeTime:  zapcore.ISO8601TimeEncoder,
+		},
+		OmitTimestamp: true,
+	})
+	logger.Info().Str("k", "v").Msg("no ts")
+
+	if got, want := buf.String(), `{"level":"info","msg":"no ts","k":"v"}`+"\n"; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
+
+func TestZapAdapter_CloseFlushesBufferedCore(t *testing.T) {
+	var buf bytes.Buffer
+	ws := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(&buf), Size: 64 << 10, FlushInterval: time.Hour}
+	defer ws.Stop()
+
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "message"})
+	a := New(zap.New(zapcore.NewCore(enc, ws, zapcore.DebugLevel)))
+	logger, err := xlog.NewBuilder().WithAdapter(a).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().Msg("buffered")
+	if buf.Len() != 0 {
+		t.Fatalf("expected output to be buffered before Close, got %q", buf.String())
+	}
+	logger.Close()
+	if !bytes.Contains(buf.Bytes(), []byte(`"message":"buffered"`)) {
+		t.Fatalf("expected buffered entry after Close, got %q", buf.String())
+	}
+}
+
+func TestZapAdapter_ObserversSeeBoundFields(t *testing.T) {
+	var buf bytes.Buffer
+	var got []xlog.Entry
+	logger, err := xlog.NewBuilder().
+		WithAdapter(New(newTestZap(&buf))).
+		AddObserver(xlog.ObserverFunc(func(e xlog.Entry) { got = append(got, e) })).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.With(xlog.Str("svc", "api")).Info().Str("path", "/healthz").Msg("ok")
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 observer entry, got %d", len(got))
+	}
+	fs := got[0].Fields
+	if len(fs) != 2 || fs[0].K != "svc" || fs[1].K != "path" {
+		t.Fatalf("observer fields = %+v, want bound svc then event path", fs)
+	}
+	if n := bytes.Count(buf.Bytes(), []byte(`"svc"`)); n != 1 {
+		t.Fatalf("bound field encoded %d times: %s", n, buf.String())
+	}
+}
+
+func TestUse_IncludeHostPID(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	// The console encoder renders bound fields in its trailing context object.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, Console: true, OmitTimestamp: true, IncludeHostPID: true})
+	logger.Info().Msg("boot")
+
+	want := fmt.Sprintf("info\tboot\t{\"host\": %q, \"pid\": %d}\n", host, os.Getpid())
+	if got := buf.String(); got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
+
+func TestUse_StrMapIntMapAsObjects(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Typed maps go through an ObjectMarshaler rather than zap.Reflect, so the
+	// console encoder renders them with its own spaced object syntax.
+	f := xlog.Any("labels", map[string]string{"a": "b"})
+	if got := toZapField(&f).Type; got != zapcore.ObjectMarshalerType {
+		t.Fatalf("StrMap field type = %v, want ObjectMarshalerType", got)
+	}
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, Console: true, OmitTimestamp: true})
+	logger.Info().
+		StrMap("labels", map[string]string{"zone": "b", "app": "api"}).
+		IntMap("counts", map[string]int64{"ok": 2, "err": 1}).
+		Msg("maps")
+	logger.Info().StrMap("labels", nil).IntMap("counts", map[string]int64{}).Msg("empty")
+
+	want := "info\tmaps\t{\"labels\": {\"app\": \"api\", \"zone\": \"b\"}, \"counts\": {\"err\": 1, \"ok\": 2}}\n" +
+		"info\tempty\t{\"labels\": {}, \"counts\": {}}\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestUse_Float32ShortestForm(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// zap.Float32 keeps the 32-bit type, so the encoder picks the shortest
+	// float32 form instead of the widened 0.10000000149011612.
+	f := xlog.Float32("ratio", 0.1)
+	if got := toZapField(&f).Type; got != zapcore.Float32Type {
+		t.Fatalf("Float32 field type = %v, want Float32Type", got)
+	}
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, Console: true, OmitTimestamp: true})
+	logger.Info().Float32("ratio", 0.1).Msg("f32")
+
+	if got, want := buf.String(), "info\tf32\t{\"ratio\": 0.1}\n"; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
+
+func TestUse_SampleRate(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Sampling happens in the xlog core, ahead of the zap core; count the
+	// console encoder's level column to see what reached zap.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, Console: true, OmitTimestamp: true, SampleRate: 0.5})
+	for i := 0; i < 1000; i++ {
+		logger.Info().Msg("tick")
+		logger.Error().Msg("tick")
+	}
+
+	var infos, errs int
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "info\t"):
+			infos++
+		case strings.HasPrefix(line, "error\t"):
+			errs++
+		default:
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+	if errs != 1000 {
+		t.Fatalf("errors must bypass sampling, got %d of 1000", errs)
+	}
+	if infos < 400 || infos > 600 {
+		t.Fatalf("got %d of 1000 info lines, want about half", infos)
+	}
+}
+
+func TestZapAdapter_DursTimesUseEncoderConfig(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:     "message",
+		EncodeDuration: zapcore.MillisDurationEncoder,
+		EncodeTime:     zapcore.RFC3339TimeEncoder,
+	})
+	a := New(zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel)))
+	logger, err := xlog.NewBuilder().WithAdapter(a).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().
+		Durs("durs", []time.Duration{1500 * time.Millisecond, 2 * time.Millisecond}).
+		Times("times", []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}).
+		Msg("slices")
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal: %v; line=%s", err, buf.String())
+	}
+	if got := string(m["durs"]); got != "[1500,2]" {
+		t.Fatalf("durs = %s, want [1500,2]", got)
+	}
+	if got := string(m["times"]); got != `["2024-01-02T03:04:05Z"]` {
+		t.Fatalf("times = %s", got)
+	}
+}
+
+func TestZapAdapter_AnyDurationUsesEncoderConfig(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:     "message",
+		EncodeDuration: zapcore.MillisDurationEncoder,
+	})
+	a := New(zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel)))
+	logger, err := xlog.NewBuilder().WithAdapter(a).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Any("took", 1500*time.Millisecond).Msg("any")
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal: %v; line=%s", err, buf.String())
+	}
+	if got := string(m["took"]); got != "1500" {
+		t.Fatalf("took = %s, want 1500", got)
+	}
+}
+
+func TestZapAdapter_DecimalUnquoted(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(newTestZap(&buf))).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Decimal("amount", "1234.5678901234567890").Msg("money")
+
+	if want := `"amount":1234.5678901234567890`; !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Fatalf("missing %s: %s", want, buf.String())
+	}
+}
+
+func TestUse_WithLevelChildLogsBelowBackendLevel(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	child := logger.With(xlog.Str("req", "r-1")).WithLevel(xlog.LevelDebug)
+
+	child.Debug().Msg("child-debug")
+	logger.Debug().Msg("parent-debug")
+	child.SetMinLevel(xlog.LevelError)
+	child.Info().Msg("child-info")
+	logger.Info().Msg("parent-info")
+
+	out := buf.String()
+	if !strings.Contains(out, "child-debug") || !strings.Contains(out, `"req":"r-1"`) {
+		t.Fatalf("child debug line missing: %s", out)
+	}
+	if strings.Contains(out, "parent-debug") || strings.Contains(out, "child-info") {
+		t.Fatalf("level leaked between parent and child: %s", out)
+	}
+	if !strings.Contains(out, "parent-info") {
+		t.Fatalf("child SetMinLevel changed the parent's backend level: %s", out)
+	}
+}
@@ -21,6 +21,9 @@ type Config struct {
 	Caller             bool                  // include caller in logs
 	CallerSkip         int                   // frames to skip when resolving caller; default 2–5 typically
 	TimestampFieldName string                // default "ts" (aligns with xlog's authoritative timestamp)
+	OmitTimestamp      bool                  // skip the ts field entirely (sink adds its own)
+	IncludeHostPID     bool                  // bind host and pid fields to every entry
+	SampleRate         float64               // keep this fraction (0..1) of entries below error; 0 keeps all
 }
 
 // Use builds a zap-backed xlog logger from Config,
@@ -80,14 +83,21 @@ func Use(cfg Config) *xlog.Logger {
 
 	// Wrap in adapter and set global
 	ad := NewWithTimestampKey(zl, &al, cfg.TimestampFieldName)
+	ad.omitTS = cfg.OmitTimestamp
 	ad.SetMinLevel(cfg.MinLevel)
 
 	// Build an xlog.Logger bound to the current process clock (xclock.Default()).
-	logger, err := xlog.NewBuilder().
+	b := xlog.NewBuilder().
 		WithAdapter(ad).
 		WithMinLevel(cfg.MinLevel).
-		WithClock(xclock.Default()).
-		Build()
+		WithClock(xclock.Default())
+	if cfg.IncludeHostPID {
+		b = b.WithFields(xlog.HostPIDFields()...)
+	}
+	if cfg.SampleRate > 0 {
+		b = b.WithSampler(xlog.NewRateSampler(cfg.SampleRate))
+	}
+	logger, err := b.Build()
 	if err != nil {
 		panic(err)
 	}
@@ -1,6 +1,9 @@
 package zerolog
 
 import (
+	"io"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,7 +19,9 @@ import (
 //     the level is disabled.
 //   - Uses Logger.WithLevel(...) to avoid a level switch at call sites.
 type Adapter struct {
-	l zerolog.Logger
+	l      zerolog.Logger
+	w      io.Writer // optional; flushed on Close when it supports it
+	omitTS bool      // skip the injected timestamp (sink adds its own)
 }
 
 func New(l zerolog.Logger) *Adapter {
@@ -55,7 +60,9 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 
 	// Ensure RFC3339Nano precision regardless of zerolog.TimeFieldFormat defaults.
 	// Using a string avoids global config changes and keeps output deterministic.
-	ev.Str("ts", at.UTC().Format(time.RFC3339Nano))
+	if !a.omitTS {
+		ev.Str("ts", at.UTC().Format(time.RFC3339Nano))
+	}
 
 	// Apply event fields
 	for i := range fields {
@@ -65,11 +72,38 @@ func (a *Adapter) Log(level xlog.Level, msg string, at time.Time, fields []xlog.
 	ev.Msg(msg)
 }
 
+// BorrowsFields reports that Log converts fields before returning and never
+// keeps the slice, letting xlog skip its defensive copy.
+func (a *Adapter) BorrowsFields() bool { return true }
+
 // SetMinLevel allows xlog.Builder to propagate min level into zerolog (optional interface).
 func (a *Adapter) SetMinLevel(l xlog.Level) {
 	a.l = a.l.Level(mapLevel(l))
 }
 
+// WithMinLevel returns a child whose zerolog logger has its own level l, so
+// xlog.Logger.WithLevel can log below the parent's. zerolog's global level
+// (zerolog.SetGlobalLevel) still applies.
+func (a *Adapter) WithMinLevel(l xlog.Level) xlog.Adapter {
+	child := *a
+	child.l = a.l.Level(mapLevel(l))
+	return &child
+}
+
+// Close flushes the writer configured via Use when it exposes Flush() error or
+// Sync() error. zerolog itself does not buffer, so otherwise this is a no-op.
+// The writer is never closed.
+func (a *Adapter) Close() error {
+	switch w := a.w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Sync() error }:
+		return w.Sync()
+	default:
+		return nil
+	}
+}
+
 // mapLevel converts xlog.Level to zerolog.Level.
 // xlog.LevelFatal is mapped to Error to avoid zerolog.Fatal() (which would exit the process).
 func mapLevel(l xlog.Level) zerolog.Level {
@@ -100,6 +134,8 @@ func appendEventField(e *zerolog.Event, f *xlog.Field) {
 		e.Uint64(f.K, f.Uint64)
 	case xlog.KindFloat64:
 		e.Float64(f.K, f.Float64)
+	case xlog.KindFloat32:
+		e.Float32(f.K, float32(f.Float64))
 	case xlog.KindBool:
 		e.Bool(f.K, f.Bool)
 	case xlog.KindDuration:
@@ -117,7 +153,20 @@ func appendEventField(e *zerolog.Event, f *xlog.Field) {
 	case xlog.KindBytes:
 		e.Bytes(f.K, f.Bytes)
 	case xlog.KindAny:
-		e.Interface(f.K, f.Any)
+		switch v := f.Any.(type) {
+		case []time.Duration:
+			e.Durs(f.K, v) // honors DurationFieldUnit/DurationFieldInteger
+		case []time.Time:
+			e.Times(f.K, v) // honors TimeFieldFormat
+		case []bool:
+			e.Bools(f.K, v)
+		default:
+			if d, ok := mapDict(f.Any); ok {
+				e.Dict(f.K, d)
+			} else {
+				e.Interface(f.K, f.Any)
+			}
+		}
 	default:
 		// Keep a placeholder to preserve shape
 		e.Interface(f.K, nil)
@@ -135,6 +184,8 @@ func appendCtxField(ctx zerolog.Context, f *xlog.Field) zerolog.Context {
 		return ctx.Uint64(f.K, f.Uint64)
 	case xlog.KindFloat64:
 		return ctx.Float64(f.K, f.Float64)
+	case xlog.KindFloat32:
+		return ctx.Float32(f.K, float32(f.Float64))
 	case xlog.KindBool:
 		return ctx.Bool(f.K, f.Bool)
 	case xlog.KindDuration:
@@ -153,8 +204,40 @@ func appendCtxField(ctx zerolog.Context, f *xlog.Field) zerolog.Context {
 	case xlog.KindBytes:
 		return ctx.Bytes(f.K, f.Bytes)
 	case xlog.KindAny:
+		switch v := f.Any.(type) {
+		case []time.Duration:
+			return ctx.Durs(f.K, v)
+		case []time.Time:
+			return ctx.Times(f.K, v)
+		case []bool:
+			return ctx.Bools(f.K, v)
+		}
+		if d, ok := mapDict(f.Any); ok {
+			return ctx.Dict(f.K, d)
+		}
 		return ctx.Interface(f.K, f.Any)
 	default:
 		return ctx.Interface(f.K, nil)
 	}
 }
+
+// mapDict renders the typed maps produced by Event.StrMap/IntMap as a zerolog
+// dict with sorted keys, avoiding the json.Marshal fallback of Interface.
+func mapDict(v any) (*zerolog.Event, bool) {
+	switch m := v.(type) {
+	case map[string]string:
+		d := zerolog.Dict()
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			d.Str(k, m[k])
+		}
+		return d, true
+	case map[string]int64:
+		d := zerolog.Dict()
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			d.Int64(k, m[k])
+		}
+		return d, true
+	default:
+		return nil, false
+	}
+}
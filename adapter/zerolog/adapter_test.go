@@ -1,9 +1,13 @@
 package zerolog
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -99,3 +103,216 @@ func TestZerologAdapter_WithBoundFields(t *testing.T) {
 		t.Fatalf("bound + event fields missing: %v", m)
 	}
 }
+
+func TestUse_OmitTimestamp(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+	lvl, msg := zerolog.LevelFieldName, zerolog.MessageFieldName
+	defer func() { zerolog.LevelFieldName, zerolog.MessageFieldName = lvl, msg }()
+	zerolog.LevelFieldName, zerolog.MessageFieldName = "severity", "msg"
+
+	// zerolog adds no timestamp of its own, and its field-name globals apply.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	logger.Info().Str("k", "v").Msg("no ts")
+
+	if got, want := buf.String(), `{"severity":"info","k":"v","msg":"no ts"}`+"\n"; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
+
+func TestUse_CloseFlushesWriter(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	logger := Use(Config{Writer: bw, MinLevel: xlog.LevelInfo})
+	logger.Info().Msg("buffered")
+	if buf.Len() != 0 {
+		t.Fatalf("expected output to be buffered before Close, got %q", buf.String())
+	}
+	logger.Close()
+	if !bytes.Contains(buf.Bytes(), []byte(`"message":"buffered"`)) {
+		t.Fatalf("expected buffered entry after Close, got %q", buf.String())
+	}
+}
+
+func TestUse_SampleEveryN(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, SampleEveryN: 10})
+	for i := 0; i < 100; i++ {
+		logger.Info().Int("i", i).Msg("sampled")
+		logger.Debug().Msg("filtered by xlog before sampling")
+	}
+
+	lines := bytes.Count(buf.Bytes(), []byte("\n"))
+	if lines != 10 {
+		t.Fatalf("expected 10 of 100 entries, got %d", lines)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("filtered by xlog")) {
+		t.Fatal("debug entry bypassed the xlog min level filter")
+	}
+}
+
+func TestUse_IncludeHostPID(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	// zerolog writes context fields right after the level, before ts.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, IncludeHostPID: true})
+	logger.Info().Msg("boot")
+
+	want := fmt.Sprintf(`{"level":"info","host":%q,"pid":%d,"ts":`, host, os.Getpid())
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Fatalf("line %s does not start with %s", buf.String(), want)
+	}
+}
+
+func TestUse_StrMapIntMapAsObjects(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Typed maps are written through zerolog.Dict, so they must not reach
+	// InterfaceMarshalFunc, the json.Marshal fallback used by Interface.
+	prevMarshal := zerolog.InterfaceMarshalFunc
+	defer func() { zerolog.InterfaceMarshalFunc = prevMarshal }()
+	zerolog.InterfaceMarshalFunc = func(v any) ([]byte, error) {
+		t.Errorf("InterfaceMarshalFunc called with %#v", v)
+		return json.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	logger.Info().
+		StrMap("labels", map[string]string{"zone": "b", "app": "api"}).
+		IntMap("counts", map[string]int64{"ok": 2, "err": 1}).
+		Msg("maps")
+	logger.Info().StrMap("labels", nil).IntMap("counts", map[string]int64{}).Msg("empty")
+
+	want := `{"level":"info","labels":{"app":"api","zone":"b"},"counts":{"err":1,"ok":2},"message":"maps"}` + "\n" +
+		`{"level":"info","labels":{},"counts":{},"message":"empty"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestUse_Float32ShortestForm(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// Event.Float32 goes through zerolog's float32 encoder, so it obeys
+	// FloatingPointPrecision like any native zerolog float.
+	prevPrec := zerolog.FloatingPointPrecision
+	defer func() { zerolog.FloatingPointPrecision = prevPrec }()
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	logger.Info().Float32("ratio", 0.1).Msg("f32")
+	zerolog.FloatingPointPrecision = 2
+	logger.Info().Float32("ratio", 0.12345).Msg("f32")
+
+	want := `{"level":"info","ratio":0.1,"message":"f32"}` + "\n" +
+		`{"level":"info","ratio":0.12,"message":"f32"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestUse_SampleRate(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	// SampleRate runs in the xlog core and stacks with zerolog's native
+	// sampler, which does not exempt errors: BasicSampler keeps every other
+	// event that reaches it whatever its level.
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true, SampleRate: 0.5, SampleEveryN: 2})
+	for i := 0; i < 1000; i++ {
+		logger.Error().Msg("tick")
+	}
+	errs := bytes.Count(buf.Bytes(), []byte(`"level":"error"`))
+	if errs != 500 {
+		t.Fatalf("got %d of 1000 error lines, want exactly 500 from BasicSampler", errs)
+	}
+
+	buf.Reset()
+	for i := 0; i < 1000; i++ {
+		logger.Info().Msg("tick")
+	}
+	infos := bytes.Count(buf.Bytes(), []byte(`"level":"info"`))
+	if infos < 175 || infos > 325 {
+		t.Fatalf("got %d of 1000 info lines, want about a quarter", infos)
+	}
+}
+
+func TestZerologAdapter_DursTimesUseGlobalEncodings(t *testing.T) {
+	// Assumes zerolog's defaults: millisecond durations, RFC3339 times.
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(zerolog.New(&buf))).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().
+		Durs("durs", []time.Duration{1500 * time.Millisecond, 2 * time.Millisecond}).
+		Times("times", []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}).
+		Msg("slices")
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("json unmarshal: %v; line=%s", err, buf.String())
+	}
+	if got := string(m["durs"]); got != "[1500,2]" {
+		t.Fatalf("durs = %s, want [1500,2]", got)
+	}
+	if got := string(m["times"]); got != `["2024-01-02T03:04:05Z"]` {
+		t.Fatalf("times = %s", got)
+	}
+}
+
+func TestZerologAdapter_DecimalUnquoted(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(New(zerolog.New(&buf))).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Decimal("amount", "1234.5678901234567890").Msg("money")
+
+	if want := `"amount":1234.5678901234567890`; !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Fatalf("missing %s: %s", want, buf.String())
+	}
+}
+
+func TestUse_WithLevelChildLogsBelowBackendLevel(t *testing.T) {
+	prev := xlog.L()
+	defer xlog.SetGlobal(prev)
+
+	var buf bytes.Buffer
+	logger := Use(Config{Writer: &buf, MinLevel: xlog.LevelInfo, OmitTimestamp: true})
+	child := logger.With(xlog.Str("req", "r-1")).WithLevel(xlog.LevelDebug)
+
+	child.Debug().Msg("child-debug")
+	logger.Debug().Msg("parent-debug")
+	child.SetMinLevel(xlog.LevelError)
+	child.Info().Msg("child-info")
+	logger.Info().Msg("parent-info")
+
+	out := buf.String()
+	if !strings.Contains(out, "child-debug") || !strings.Contains(out, `"req":"r-1"`) {
+		t.Fatalf("child debug line missing: %s", out)
+	}
+	if strings.Contains(out, "parent-debug") || strings.Contains(out, "child-info") {
+		t.Fatalf("level leaked between parent and child: %s", out)
+	}
+	if !strings.Contains(out, "parent-info") {
+		t.Fatalf("child SetMinLevel changed the parent's backend level: %s", out)
+	}
+}
@@ -20,6 +20,19 @@ type Config struct {
 	Caller             bool   // include caller in logs
 	CallerSkip         int    // frames to skip when resolving caller; default 5
 	TimestampFieldName string // default "ts" (aligns with xlog's authoritative timestamp)
+	OmitTimestamp      bool   // skip the ts field entirely (sink adds its own)
+	IncludeHostPID     bool   // bind host and pid fields to every entry
+
+	// Sampler applies zerolog's native sampling after xlog's min level filter.
+	// SampleEveryN is a shortcut for zerolog.BasicSampler{N: SampleEveryN};
+	// Sampler takes precedence when both are set.
+	Sampler      zerolog.Sampler
+	SampleEveryN int
+
+	// SampleRate keeps this fraction (0..1) of entries below error, sampled in
+	// the xlog core (xlog.NewRateSampler) so observers see the same stream.
+	// 0 keeps all.
+	SampleRate float64
 }
 
 // Use builds a zerolog-backed xlog logger from Config, wires it as the global
@@ -56,6 +69,14 @@ func Use(cfg Config) *xlog.Logger {
 	// Level
 	zl = zl.Level(mapLevel(cfg.MinLevel))
 
+	// Sampling
+	switch {
+	case cfg.Sampler != nil:
+		zl = zl.Sample(cfg.Sampler)
+	case cfg.SampleEveryN > 1:
+		zl = zl.Sample(&zerolog.BasicSampler{N: uint32(cfg.SampleEveryN)})
+	}
+
 	// Caller
 	if cfg.Caller {
 		zerolog.CallerSkipFrameCount = cfg.CallerSkip
@@ -64,15 +85,23 @@ func Use(cfg Config) *xlog.Logger {
 
 	// Wrap in adapter
 	ad := New(zl)
+	ad.w = w
+	ad.omitTS = cfg.OmitTimestamp
 	// Propagate min level down to zerolog (optional interface)
 	ad.SetMinLevel(cfg.MinLevel)
 
 	// Build an xlog.Logger bound to the current process clock (xclock.Default()).
-	logger, err := xlog.NewBuilder().
+	b := xlog.NewBuilder().
 		WithAdapter(ad).
 		WithMinLevel(cfg.MinLevel).
-		WithClock(xclock.Default()).
-		Build()
+		WithClock(xclock.Default())
+	if cfg.IncludeHostPID {
+		b = b.WithFields(xlog.HostPIDFields()...)
+	}
+	if cfg.SampleRate > 0 {
+		b = b.WithSampler(xlog.NewRateSampler(cfg.SampleRate))
+	}
+	logger, err := b.Build()
 	if err != nil {
 		// In practice, Build only fails with a nil adapter which cannot happen here.
 		// Keep panic to surface programming errors early.
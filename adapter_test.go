@@ -0,0 +1,56 @@
+package xlog
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestObserverAdapter_ObserversAreTheOnlySink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		got []Entry
+	)
+	logger, err := NewBuilder().
+		WithAdapter(ObserverAdapter()).
+		WithFields(Str("svc", "api")).
+		AddObserver(ObserverFunc(func(e Entry) {
+			mu.Lock()
+			got = append(got, e)
+			mu.Unlock()
+		})).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().Str("k", "v").Msg("one")
+	logger.With(Str("child", "x")).Warn().Msg("two")
+	logger.Debug().Msg("filtered")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 observed entries, got %d", len(got))
+	}
+	if got[0].Message != "one" || got[1].Level != LevelWarn {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+	assertHasStr(t, got[0].Fields, "svc", "api")
+	assertHasStr(t, got[0].Fields, "k", "v")
+	assertHasStr(t, got[1].Fields, "child", "x")
+}
+
+func TestLoggerSetWriter_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	if logger.SetWriter(io.Discard) {
+		t.Fatal("SetWriter reported success for an adapter without SetWriter")
+	}
+}
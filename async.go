@@ -0,0 +1,98 @@
+package xlog
+
+import (
+	"io"
+	"sync"
+)
+
+// asyncObserver decouples a slow Observer from the emit path with a bounded
+// queue drained by a single background goroutine.
+type asyncObserver struct {
+	inner  Observer
+	onDrop func(Entry)
+	queue  chan asyncItem
+
+	mu     sync.RWMutex // guards closed against sends on a closed queue
+	closed bool
+	once   sync.Once
+	done   chan struct{}
+}
+
+// asyncItem carries either an entry or a config change, preserving order.
+type asyncItem struct {
+	e   Entry
+	cfg *ConfigChange
+}
+
+// NewAsyncObserver returns an Observer that hands notifications to inner on a
+// background goroutine. When the queue of queueSize entries is full, entries
+// are dropped and passed to onDrop (if non-nil) instead of blocking emit.
+// The returned Closer stops intake and blocks until queued entries are
+// delivered; call it on shutdown.
+func NewAsyncObserver(inner Observer, queueSize int, onDrop func(Entry)) (Observer, io.Closer) {
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	a := &asyncObserver{
+		inner:  inner,
+		onDrop: onDrop,
+		queue:  make(chan asyncItem, queueSize),
+		done:   make(chan struct{}),
+	}
+	go a.run()
+	return a, a
+}
+
+func (a *asyncObserver) OnEvent(e Entry) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return
+	}
+	select {
+	case a.queue <- asyncItem{e: e}:
+	default:
+		if a.onDrop != nil {
+			a.onDrop(e)
+		}
+	}
+}
+
+// OnConfig enqueues config changes behind pending entries; they are rare, so
+// this blocks rather than drops when the queue is full.
+func (a *asyncObserver) OnConfig(c ConfigChange) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return
+	}
+	a.queue <- asyncItem{cfg: &c}
+}
+
+// Close stops accepting notifications and drains the queue.
+func (a *asyncObserver) Close() error {
+	a.once.Do(func() {
+		a.mu.Lock()
+		a.closed = true
+		close(a.queue)
+		a.mu.Unlock()
+	})
+	<-a.done
+	return nil
+}
+
+func (a *asyncObserver) run() {
+	defer close(a.done)
+	for it := range a.queue {
+		a.deliver(it)
+	}
+}
+
+func (a *asyncObserver) deliver(it asyncItem) {
+	defer func() { _ = recover() }()
+	if it.cfg != nil {
+		a.inner.OnConfig(*it.cfg)
+		return
+	}
+	a.inner.OnEvent(it.e)
+}
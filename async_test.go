@@ -0,0 +1,60 @@
+package xlog
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncObserver_NonBlockingAndDrainsOnClose(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	picked := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var got []string
+	slow := ObserverFunc(func(e Entry) {
+		select {
+		case picked <- struct{}{}:
+		default:
+		}
+		<-release
+		mu.Lock()
+		got = append(got, e.Message)
+		mu.Unlock()
+	})
+
+	var dropped atomic.Int32
+	obs, closer := NewAsyncObserver(slow, 2, func(Entry) { dropped.Add(1) })
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).AddObserver(obs).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	start := time.Now()
+	// First entry is taken by the worker (blocked in inner), two fill the
+	// queue, and the rest must be dropped without blocking emit.
+	logger.Info().Msg("m1")
+	<-picked
+	for _, m := range []string{"m2", "m3", "m4", "m5"} {
+		logger.Info().Msg(m)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("emit blocked on slow observer for %s", elapsed)
+	}
+	if n := dropped.Load(); n != 2 {
+		t.Fatalf("expected 2 drops, got %d", n)
+	}
+
+	close(release)
+	_ = closer.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 3 || got[0] != "m1" || got[1] != "m2" || got[2] != "m3" {
+		t.Fatalf("expected m1..m3 delivered in order after Close, got %v", got)
+	}
+
+	logger.Info().Msg("after close") // must not panic
+}
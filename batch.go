@@ -0,0 +1,117 @@
+package xlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/trickstertwo/xclock"
+)
+
+// BatchObserver receives emitted entries in batches rather than one at a time.
+// Implementations own the slice passed to OnLogBatch and MUST be concurrency-safe.
+type BatchObserver interface {
+	OnLogBatch(entries []Entry)
+}
+
+// BatchingObserver is an Observer that buffers entries and forwards them to a
+// BatchObserver when the buffer reaches maxBatch entries or when maxDelay has
+// elapsed since the first buffered entry, whichever comes first.
+//
+// Batches are delivered in emission order. Call Close (or Flush) on shutdown
+// so buffered entries are not lost.
+type BatchingObserver struct {
+	inner    BatchObserver
+	maxBatch int
+	maxDelay time.Duration
+	clock    xclock.Clock
+
+	mu     sync.Mutex
+	buf    []Entry
+	cancel xclock.CancelFunc // pending delay flush, if any
+	closed bool
+
+	sendMu sync.Mutex // serializes delivery to preserve batch order
+}
+
+// NewBatchingObserver wraps inner with size- and time-based batching.
+// maxBatch <= 0 disables size-based flushing; maxDelay <= 0 disables
+// time-based flushing. Timers run on xclock.Default() unless set via
+// WithClock.
+func NewBatchingObserver(inner BatchObserver, maxBatch int, maxDelay time.Duration) *BatchingObserver {
+	return &BatchingObserver{
+		inner:    inner,
+		maxBatch: maxBatch,
+		maxDelay: maxDelay,
+		clock:    xclock.Default(),
+	}
+}
+
+// WithClock sets the clock that schedules delay flushes (e.g. a test clock).
+// Call it before the observer receives entries.
+func (b *BatchingObserver) WithClock(c xclock.Clock) *BatchingObserver {
+	if c != nil {
+		b.clock = c
+	}
+	return b
+}
+
+// OnEvent buffers e and flushes when the batch is full.
+func (b *BatchingObserver) OnEvent(e Entry) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.buf = append(b.buf, e)
+	if b.maxBatch > 0 && len(b.buf) >= b.maxBatch {
+		b.flushLocked()
+		return
+	}
+	if len(b.buf) == 1 && b.maxDelay > 0 {
+		b.cancel = b.clock.AfterFunc(b.maxDelay, b.Flush)
+	}
+	b.mu.Unlock()
+}
+
+// OnConfig forwards config changes when inner also implements Observer.
+func (b *BatchingObserver) OnConfig(c ConfigChange) {
+	if o, ok := b.inner.(Observer); ok {
+		o.OnConfig(c)
+	}
+}
+
+// Flush delivers any buffered entries immediately.
+func (b *BatchingObserver) Flush() {
+	b.mu.Lock()
+	b.flushLocked()
+}
+
+// Close flushes remaining entries and stops accepting new ones. Safe to call
+// more than once.
+func (b *BatchingObserver) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.flushLocked()
+	return nil
+}
+
+// flushLocked takes the current batch and delivers it. It must be called with
+// b.mu held and releases it before invoking inner.
+func (b *BatchingObserver) flushLocked() {
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+	batch := b.buf
+	b.buf = nil
+	if len(batch) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	// Acquire sendMu before releasing mu so batches are delivered in order.
+	b.sendMu.Lock()
+	b.mu.Unlock()
+	defer b.sendMu.Unlock()
+	defer func() { _ = recover() }()
+	b.inner.OnLogBatch(batch)
+}
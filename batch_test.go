@@ -0,0 +1,119 @@
+package xlog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xclock"
+	"github.com/trickstertwo/xclock/adapter/frozen"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]Entry
+}
+
+func (r *batchRecorder) OnLogBatch(entries []Entry) {
+	r.mu.Lock()
+	r.batches = append(r.batches, entries)
+	r.mu.Unlock()
+}
+
+func (r *batchRecorder) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]int, len(r.batches))
+	for i, b := range r.batches {
+		out[i] = len(b)
+	}
+	return out
+}
+
+func TestBatchingObserver_FlushesByCount(t *testing.T) {
+	t.Parallel()
+
+	rec := &batchRecorder{}
+	bo := NewBatchingObserver(rec, 3, 0)
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).AddObserver(bo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	for i := 0; i < 7; i++ {
+		logger.Info().Int("i", i).Msg("tick")
+	}
+	if got := rec.sizes(); len(got) != 2 || got[0] != 3 || got[1] != 3 {
+		t.Fatalf("expected two full batches before close, got %v", got)
+	}
+
+	_ = bo.Close()
+	got := rec.sizes()
+	if len(got) != 3 || got[2] != 1 {
+		t.Fatalf("expected remainder flushed on close, got %v", got)
+	}
+	rec.mu.Lock()
+	last := rec.batches[2][0]
+	rec.mu.Unlock()
+	assertHasInt64(t, last.Fields, "i", 6)
+
+	logger.Info().Msg("after close")
+	if n := len(rec.sizes()); n != 3 {
+		t.Fatalf("expected no batches after close, got %d", n)
+	}
+}
+
+// timerClock records AfterFunc calls so tests fire them explicitly.
+type timerClock struct {
+	xclock.Clock
+	mu    sync.Mutex
+	delay time.Duration
+	fn    func()
+}
+
+func (c *timerClock) AfterFunc(d time.Duration, fn func()) xclock.CancelFunc {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delay, c.fn = d, fn
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		active := c.fn != nil
+		c.fn = nil
+		return active
+	}
+}
+
+// fire runs the pending AfterFunc callback, if any.
+func (c *timerClock) fire() {
+	c.mu.Lock()
+	fn := c.fn
+	c.fn = nil
+	c.mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+func TestBatchingObserver_FlushesByDelay(t *testing.T) {
+	t.Parallel()
+
+	clk := &timerClock{Clock: frozen.New(time.Unix(0, 0))}
+	rec := &batchRecorder{}
+	bo := NewBatchingObserver(rec, 100, 20*time.Millisecond).WithClock(clk)
+	defer bo.Close()
+
+	bo.OnEvent(Entry{Level: LevelInfo, Message: "a"})
+	bo.OnEvent(Entry{Level: LevelInfo, Message: "b"})
+	if got := rec.sizes(); len(got) != 0 {
+		t.Fatalf("flushed before the delay elapsed: %v", got)
+	}
+	if clk.delay != 20*time.Millisecond {
+		t.Fatalf("scheduled flush after %v, want 20ms", clk.delay)
+	}
+
+	clk.fire()
+	if got := rec.sizes(); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected one batch of 2, got %v", got)
+	}
+}
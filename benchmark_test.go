@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/trickstertwo/xclock"
+	"github.com/trickstertwo/xclock/adapter/frozen"
 )
 
 // blackhole variables prevent compiler from optimizing away code paths.
@@ -14,11 +15,11 @@ var (
 	bhLen int
 )
 
-type nopAdapter struct {
+type benchAdapter struct {
 	bound []Field
 }
 
-func (a *nopAdapter) With(fs []Field) Adapter {
+func (a *benchAdapter) With(fs []Field) Adapter {
 	child := *a
 	if len(a.bound) > 0 {
 		child.bound = append([]Field(nil), a.bound...)
@@ -27,7 +28,7 @@ func (a *nopAdapter) With(fs []Field) Adapter {
 	return &child
 }
 
-func (a *nopAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
+func (a *benchAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
 	// Touch inputs to avoid elimination; do not allocate.
 	if len(a.bound)+len(fields) == -1 {
 		bhI++
@@ -36,9 +37,19 @@ func (a *nopAdapter) Log(level Level, msg string, at time.Time, fields []Field)
 	bhLen = len(fields)
 }
 
+// borrowBenchAdapter is benchAdapter declaring it never retains fields, which
+// lets the logger skip its defensive copy.
+type borrowBenchAdapter struct{ benchAdapter }
+
+func (a *borrowBenchAdapter) With(fs []Field) Adapter {
+	return &borrowBenchAdapter{*a.benchAdapter.With(fs).(*benchAdapter)}
+}
+
+func (*borrowBenchAdapter) BorrowsFields() bool { return true }
+
 func newBenchLogger(min Level) *Logger {
 	l, err := NewBuilder().
-		WithAdapter(&nopAdapter{}).
+		WithAdapter(&benchAdapter{}).
 		WithMinLevel(min).
 		Build()
 	if err != nil {
@@ -71,6 +82,26 @@ func BenchmarkInfo_5Fields(b *testing.B) {
 	}
 }
 
+// BenchmarkInfo_5Fields_Borrowed is BenchmarkInfo_5Fields against an adapter
+// implementing adapterBorrowsFields: one allocation fewer (the field copy).
+func BenchmarkInfo_5Fields_Borrowed(b *testing.B) {
+	l, err := NewBuilder().WithAdapter(&borrowBenchAdapter{}).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info().
+			Str("a", "b").
+			Int("i", i).
+			Bool("ok", true).
+			Dur("d", time.Millisecond*25).
+			Float64("f", 1.23).
+			Msg("five")
+	}
+}
+
 func BenchmarkInfo_10Fields(b *testing.B) {
 	l := newBenchLogger(LevelDebug)
 	b.ReportAllocs()
@@ -171,7 +202,7 @@ func BenchmarkParallel_10Fields(b *testing.B) {
 func BenchmarkInfo_FrozenClock(b *testing.B) {
 	orig := xclock.Default()
 	defer xclock.SetDefault(orig)
-	xclock.SetDefault(xclock.NewFrozen(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	xclock.SetDefault(frozen.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
 
 	l := newBenchLogger(LevelDebug)
 	b.ReportAllocs()
@@ -180,3 +211,29 @@ func BenchmarkInfo_FrozenClock(b *testing.B) {
 		l.Info().Str("k", "v").Msg("frozen")
 	}
 }
+
+// BenchmarkInfo_ErrorGatedObserver compares an error-gated observer with an
+// ungated one: in the gated case info entries skip building the observer
+// Entry, saving its fields copy (one allocation per op).
+func BenchmarkInfo_ErrorGatedObserver(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		add  func(*Builder, Observer) *Builder
+	}{
+		{"gated", func(bl *Builder, o Observer) *Builder { return bl.AddObserverAt(o, LevelError) }},
+		{"ungated", func(bl *Builder, o Observer) *Builder { return bl.AddObserver(o) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			obs := ObserverFunc(func(e Entry) { bhLen = len(e.Fields) })
+			l, err := bc.add(NewBuilder().WithAdapter(&benchAdapter{}).WithMinLevel(LevelDebug), obs).Build()
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.LogAt(LevelInfo, "ok", Str("a", "b"))
+			}
+		})
+	}
+}
@@ -1,13 +1,34 @@
 package xlog
 
-import "github.com/trickstertwo/xclock"
+import (
+	"time"
+
+	"github.com/trickstertwo/xclock"
+)
 
 // Config for constructing a Logger (Factory data structure).
 type Config struct {
-	Adapter   Adapter
-	MinLevel  Level
-	Observers []Observer
-	Clock     xclock.Clock // optional; defaults to xclock.System()
+	Adapter       Adapter
+	MinLevel      Level
+	Observers     []Observer
+	Clock         xclock.Clock  // optional; defaults to xclock.Default()
+	Fields        []Field       // optional; bound to the adapter once at construction
+	Sampler       Sampler       // optional; consulted after the min level filter
+	KeyPrefix     string        // optional; prepended to every bound and event field key
+	PrintLevel    Level         // level used by Logger.Printf/Println; zero value is LevelInfo
+	InstanceID    string        // optional; bound as "instance_id" and reported by Logger.InstanceID
+	SlowThreshold time.Duration // Logger.TimeOp logs at warn above this; 0 never escalates
+	Caller        CallerFormat  // optional; adds a "caller" field in this format; zero disables
+
+	auto      []autoField // set via Builder.WithAutoField
+	autoStack bool        // set via Builder.WithAutoStack
+	stackMin  Level
+}
+
+// autoField generates a field at emit time unless one with key is present.
+type autoField struct {
+	key string
+	gen func() Field
 }
 
 // Builder separates construction from representation (Builder pattern).
@@ -34,11 +55,109 @@ func (b *Builder) WithClock(c xclock.Clock) *Builder {
 	return b
 }
 
+// WithFields binds fields to every entry emitted by the built Logger.
+func (b *Builder) WithFields(fs ...Field) *Builder {
+	b.cfg.Fields = append(b.cfg.Fields, fs...)
+	return b
+}
+
+// WithComponent binds the conventional "component" and "version" fields.
+func (b *Builder) WithComponent(name, version string) *Builder {
+	return b.WithFields(Str("component", name), Str("version", version))
+}
+
+// WithRuntimeInfo binds RuntimeFields (host, pid, Go version, ...).
+func (b *Builder) WithRuntimeInfo() *Builder {
+	return b.WithFields(RuntimeFields()...)
+}
+
+// WithInstanceID tags the built Logger with a freshly generated id (see
+// GenerateID), bound as "instance_id" and available via Logger.InstanceID, to
+// tell apart instances of a multi-instance deployment. The id is generated
+// once per call.
+func (b *Builder) WithInstanceID() *Builder {
+	b.cfg.InstanceID = GenerateID()
+	return b
+}
+
+// WithKeyPrefix prefixes every field key (bound and event) with prefix, e.g.
+// "app_", giving a flat namespace on any adapter. An empty prefix is a no-op.
+func (b *Builder) WithKeyPrefix(prefix string) *Builder {
+	b.cfg.KeyPrefix = prefix
+	return b
+}
+
+// WithAutoField makes every entry carry key: when neither the bound fields nor
+// the event fields contain it, gen is called at emit time and its result is
+// appended under key. Typical use is a correlation id:
+//
+//	b.WithAutoField("request_id", func() xlog.Field { return xlog.Str("request_id", xlog.GenerateID()) })
+func (b *Builder) WithAutoField(key string, gen func() Field) *Builder {
+	b.cfg.auto = append(b.cfg.auto, autoField{key: key, gen: gen})
+	return b
+}
+
+// WithDefaults sets fields that every entry carries unless an event (or
+// bound) field with the same key overrides it, instead of being duplicated
+// as WithFields would. Defaults are appended after the event fields.
+//
+//	b.WithDefaults(xlog.Str("env", "prod")) // Info().Str("env", "staging") logs env=staging once
+func (b *Builder) WithDefaults(fields ...Field) *Builder {
+	for _, f := range fields {
+		b.cfg.auto = append(b.cfg.auto, autoField{key: f.K, gen: func() Field { return f }})
+	}
+	return b
+}
+
+// WithAutoStack attaches a "stack" field with the caller's stack trace (xlog's
+// own frames skipped) to every entry at or above min, unless the entry
+// already has one. Lower levels pay only a comparison.
+func (b *Builder) WithAutoStack(min Level) *Builder {
+	b.cfg.autoStack = true
+	b.cfg.stackMin = min
+	return b
+}
+
+// WithCaller adds a "caller" field locating the log call site, formatted per
+// format, to every entry that does not already carry one. Frames inside xlog
+// (event builders, global helpers, TimeOp, ...) are skipped.
+func (b *Builder) WithCaller(format CallerFormat) *Builder {
+	b.cfg.Caller = format
+	return b
+}
+
+// WithSlowThreshold makes Logger.TimeOp log operations slower than d at warn
+// instead of debug.
+func (b *Builder) WithSlowThreshold(d time.Duration) *Builder {
+	b.cfg.SlowThreshold = d
+	return b
+}
+
+// WithPrintLevel sets the level used by Logger.Printf and Logger.Println.
+func (b *Builder) WithPrintLevel(l Level) *Builder {
+	b.cfg.PrintLevel = l
+	return b
+}
+
+// WithSampler installs a Sampler consulted for every entry that passes the
+// min level filter.
+func (b *Builder) WithSampler(s Sampler) *Builder {
+	b.cfg.Sampler = s
+	return b
+}
+
 func (b *Builder) AddObserver(o Observer) *Builder {
 	b.cfg.Observers = append(b.cfg.Observers, o)
 	return b
 }
 
+// AddObserverAt registers o for entries at or above min only (config changes
+// are always delivered). When every observer is gated above an entry's level,
+// the Logger skips building the observer Entry altogether.
+func (b *Builder) AddObserverAt(o Observer, min Level) *Builder {
+	return b.AddObserver(levelObserver{Observer: o, min: min})
+}
+
 // Build constructs the Logger (Factory + Builder).
 func (b *Builder) Build() (*Logger, error) {
 	if b.cfg.Adapter == nil {
@@ -55,6 +174,14 @@ type adapterLevelSetter interface {
 	SetMinLevel(Level)
 }
 
+// adapterLevelForker is an optional interface for adapters whose backend
+// filters on a level shared by all children (zap AtomicLevel, slog LevelVar,
+// zerolog logger level). WithMinLevel returns a child whose backend filters at
+// min on its own, so Logger.WithLevel can go below the shared level.
+type adapterLevelForker interface {
+	WithMinLevel(Level) Adapter
+}
+
 // applyAdapterConfig applies Config-derived settings to the adapter if it
 // supports them via optional interfaces (like adapterLevelSetter).
 func (b *Builder) applyAdapterConfig(a Adapter) {
@@ -0,0 +1,43 @@
+package xlog
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	buildInfoOnce   sync.Once
+	buildInfoFields []Field
+)
+
+// BuildInfoFields returns release metadata from debug.ReadBuildInfo for
+// binding via With: build_version (main module version, e.g. "v1.2.3" or
+// "(devel)"), vcs_revision and build_time (the commit time recorded by the go
+// tool). Keys are always present; values are empty when the binary carries
+// no build or VCS info (e.g. test binaries, -buildvcs=false). Values are
+// captured on the first call and cached; each call returns a fresh copy.
+func BuildInfoFields() []Field {
+	buildInfoOnce.Do(func() {
+		var version, revision, at string
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			version = bi.Main.Version
+			for _, s := range bi.Settings {
+				switch s.Key {
+				case "vcs.revision":
+					revision = s.Value
+				case "vcs.time":
+					at = s.Value
+				}
+			}
+		}
+		buildInfoFields = []Field{
+			Str("build_version", version),
+			Str("vcs_revision", revision),
+			Str("build_time", at),
+		}
+	})
+	return copyFields(nil, buildInfoFields)
+}
+
+// WithBuildInfo binds BuildInfoFields to the logger.
+func (b *Builder) WithBuildInfo() *Builder { return b.WithFields(BuildInfoFields()...) }
@@ -0,0 +1,23 @@
+package xlog
+
+import "testing"
+
+func TestBuilderWithBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).WithBuildInfo().Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Msg("started")
+
+	fs := logger.ad.(*stubAdapter).logs[0].Fields // bound fields live on the derived adapter
+	for _, k := range []string{"build_version", "vcs_revision", "build_time"} {
+		if !hasKey(fs, k) {
+			t.Errorf("missing %q in %+v", k, fs)
+		}
+	}
+	if got := BuildInfoFields(); &got[0] == &BuildInfoFields()[0] {
+		t.Error("BuildInfoFields should return a fresh copy")
+	}
+}
@@ -0,0 +1,68 @@
+package xlog
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CallerFormat selects how Builder.WithCaller renders the "caller" field.
+// The zero value disables caller capture.
+type CallerFormat uint8
+
+const (
+	CallerFileLine     CallerFormat = iota + 1 // /path/to/file.go:42
+	CallerFuncFileLine                         // pkg.Func /path/to/file.go:42
+	CallerFunc                                 // pkg.Func:42
+)
+
+// pkgDir is the directory of xlog's own sources; frames from non-test files
+// there are skipped when looking for the caller.
+var pkgDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerDepth bounds the frames searched for the first non-xlog caller.
+const callerDepth = 16
+
+// captureCaller returns the first frame outside xlog formatted per format.
+func captureCaller(format CallerFormat) string {
+	var pcs [callerDepth]uintptr
+	n := runtime.Callers(3, pcs[:]) // skip Callers, captureCaller, emit
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !isInternalFrame(f.File) {
+			return formatCaller(format, f)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isInternalFrame(file string) bool {
+	return filepath.Dir(file) == pkgDir && !strings.HasSuffix(file, "_test.go")
+}
+
+func formatCaller(format CallerFormat, f runtime.Frame) string {
+	line := strconv.Itoa(f.Line)
+	switch format {
+	case CallerFuncFileLine:
+		return shortFunc(f.Function) + " " + f.File + ":" + line
+	case CallerFunc:
+		return shortFunc(f.Function) + ":" + line
+	default:
+		return f.File + ":" + line
+	}
+}
+
+// shortFunc trims the import path, leaving pkg.Func (or pkg.(*T).Method).
+func shortFunc(fn string) string {
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		return fn[i+1:]
+	}
+	return fn
+}
@@ -0,0 +1,68 @@
+package xlog
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func callerField(t *testing.T, fs []Field) string {
+	t.Helper()
+	for _, f := range fs {
+		if f.K == "caller" && f.Kind == KindString {
+			return f.Str
+		}
+	}
+	t.Fatalf("missing caller field in %+v", fs)
+	return ""
+}
+
+func TestWithCaller_Formats(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		format CallerFormat
+		want   func(file string, line int) string
+	}{
+		{CallerFileLine, func(file string, line int) string { return file + ":" + strconv.Itoa(line) }},
+		{CallerFuncFileLine, func(file string, line int) string {
+			return "xlog.TestWithCaller_Formats " + file + ":" + strconv.Itoa(line)
+		}},
+		{CallerFunc, func(_ string, line int) string { return "xlog.TestWithCaller_Formats:" + strconv.Itoa(line) }},
+	} {
+		adapter := newStubAdapter(nil)
+		logger, err := NewBuilder().WithAdapter(adapter).WithCaller(tc.format).Build()
+		if err != nil {
+			t.Fatalf("build logger: %v", err)
+		}
+		_, file, line, _ := runtime.Caller(0)
+		logger.Info().Msg("here") // must stay on the line after runtime.Caller
+
+		if got, want := callerField(t, adapter.logs[0].Fields), tc.want(file, line+1); got != want {
+			t.Errorf("format %d: caller = %q, want %q", tc.format, got, want)
+		}
+	}
+}
+
+func TestWithCaller_SkipsInternalFrames(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelDebug).WithCaller(CallerFunc).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.TimeOp("op")()
+	logger.Info().Str("k", "v").Msg("event")
+	logger.LogAt(LevelInfo, "explicit", Str("caller", "mine"))
+
+	for _, e := range adapter.logs[:2] {
+		if got := callerField(t, e.Fields); !strings.HasPrefix(got, "xlog.TestWithCaller_SkipsInternalFrames:") {
+			t.Errorf("%s: caller = %q, want the test function", e.Msg, got)
+		}
+	}
+	if got := callerField(t, adapter.logs[2].Fields); got != "mine" {
+		t.Errorf("explicit caller overwritten: %q", got)
+	}
+}
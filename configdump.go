@@ -0,0 +1,144 @@
+package xlog
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// redacted replaces the value of fields tagged `log:"redact"`.
+const redacted = "[REDACTED]"
+
+// cyclic replaces a pointer, map or slice already being walked higher up,
+// e.g. a child's back-pointer to its parent.
+const cyclic = "[CYCLE]"
+
+// LogConfig logs cfg once at Info as a nested "config" object, typically at
+// startup. cfg is a struct or pointer to struct; exported fields are included
+// under their Go name, nested structs become nested objects, fields tagged
+// `log:"-"` are skipped and fields tagged `log:"redact"` are masked. Structs
+// inside slices, arrays, maps and interfaces get the same treatment. Other
+// values are logged as-is, and a reference back to a value being walked is
+// logged as "[CYCLE]". It uses reflection, so keep it off hot paths.
+func LogConfig(log *Logger, cfg any) {
+	if !log.Enabled(LevelInfo) {
+		return
+	}
+	log.Info().Any("config", configValue(reflect.ValueOf(cfg), map[visit]bool{})).Msg("effective configuration")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// visit identifies a pointer, map or slice on the path configValue is
+// walking. The type and length tell apart a struct and its first field, or
+// slices sharing a backing array.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	n   int
+}
+
+// configValue converts v for LogConfig. path holds the references being
+// walked above v; they are removed on return, so values shared by siblings
+// are still rendered in full.
+func configValue(v reflect.Value, path map[visit]bool) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			key := visit{v.Pointer(), v.Type(), 0}
+			if path[key] {
+				return cyclic
+			}
+			path[key] = true
+			defer delete(path, key)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !containsStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return nil
+			}
+			key := visit{v.Pointer(), v.Type(), v.Len()}
+			if path[key] {
+				return cyclic
+			}
+			path[key] = true
+			defer delete(path, key)
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = configValue(v.Index(i), path)
+		}
+		return out
+	case reflect.Map:
+		if !containsStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+		if v.IsNil() {
+			return nil
+		}
+		key := visit{v.Pointer(), v.Type(), 0}
+		if path[key] {
+			return cyclic
+		}
+		path[key] = true
+		defer delete(path, key)
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = configValue(iter.Value(), path)
+		}
+		return out
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+	default:
+		return v.Interface()
+	}
+	t := v.Type()
+	out := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		switch sf.Tag.Get("log") {
+		case "-":
+			continue
+		case "redact":
+			out[sf.Name] = redacted
+		default:
+			out[sf.Name] = configValue(v.Field(i), path)
+		}
+	}
+	return out
+}
+
+// containsStruct reports whether values of type t may hold a struct that
+// configValue has to walk for tags. Interfaces count, since their dynamic
+// value is unknown until runtime.
+func containsStruct(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Struct:
+			return t != timeType
+		case reflect.Interface:
+			return true
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+}
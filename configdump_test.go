@@ -0,0 +1,142 @@
+package xlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLogConfig(t *testing.T) {
+	t.Parallel()
+
+	type db struct {
+		Host     string
+		Password string `log:"redact"`
+	}
+	type config struct {
+		Addr    string
+		Timeout time.Duration
+		APIKey  string `log:"-"`
+		DB      *db
+		secret  string
+	}
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	LogConfig(logger, &config{
+		Addr:    ":8080",
+		Timeout: time.Second,
+		APIKey:  "k-123",
+		DB:      &db{Host: "db1", Password: "hunter2"},
+		secret:  "s",
+	})
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	e := adapter.logs[0]
+	if e.Level != LevelInfo || len(e.Fields) != 1 || e.Fields[0].K != "config" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	got, err := json.Marshal(e.Fields[0].Any)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"Addr":":8080","DB":{"Host":"db1","Password":"[REDACTED]"},"Timeout":1000000000}`
+	if string(got) != want {
+		t.Fatalf("config = %s, want %s", got, want)
+	}
+}
+
+func TestLogConfig_RedactsInsideCollections(t *testing.T) {
+	t.Parallel()
+
+	type db struct {
+		Host     string
+		Password string `log:"redact"`
+		Token    string `log:"-"`
+	}
+	type config struct {
+		Replicas []db
+		Pinned   [1]*db
+		ByName   map[string]db
+		Extra    []any
+		Ports    []int
+	}
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	LogConfig(logger, config{
+		Replicas: []db{{Host: "r1", Password: "hunter2", Token: "t1"}},
+		Pinned:   [1]*db{{Host: "p1", Password: "pw", Token: "t2"}},
+		ByName:   map[string]db{"main": {Host: "m1", Password: "s3cret", Token: "t3"}},
+		Extra:    []any{db{Host: "x1", Password: "xpw"}, 7},
+		Ports:    []int{80, 443},
+	})
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	got, err := json.Marshal(adapter.logs[0].Fields[0].Any)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"ByName":{"main":{"Host":"m1","Password":"[REDACTED]"}},` +
+		`"Extra":[{"Host":"x1","Password":"[REDACTED]"},7],` +
+		`"Pinned":[{"Host":"p1","Password":"[REDACTED]"}],` +
+		`"Ports":[80,443],` +
+		`"Replicas":[{"Host":"r1","Password":"[REDACTED]"}]}`
+	if string(got) != want {
+		t.Fatalf("config = %s, want %s", got, want)
+	}
+}
+
+func TestLogConfig_Cycles(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Name     string
+		Parent   *node
+		Children []*node
+		Extra    map[string]any
+	}
+	shared := &node{Name: "shared"}
+	root := &node{Name: "root", Extra: map[string]any{}}
+	root.Extra["self"] = root.Extra
+	child := &node{Name: "child", Parent: root}
+	root.Children = []*node{child, shared, shared}
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	LogConfig(logger, root)
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	got, err := json.Marshal(adapter.logs[0].Fields[0].Any)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	leaf := func(name string) string {
+		return `{"Children":null,"Extra":null,"Name":"` + name + `","Parent":null}`
+	}
+	want := `{"Children":[` +
+		`{"Children":null,"Extra":null,"Name":"child","Parent":"[CYCLE]"},` +
+		leaf("shared") + `,` + leaf("shared") +
+		`],"Extra":{"self":"[CYCLE]"},"Name":"root","Parent":null}`
+	if string(got) != want {
+		t.Fatalf("config =\n%s\nwant\n%s", got, want)
+	}
+}
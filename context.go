@@ -0,0 +1,39 @@
+package xlog
+
+import "context"
+
+// ctxFieldsKey is the private context key for ContextWithFields.
+type ctxFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying fs in addition to any fields
+// already stored by an outer ContextWithFields call. Events created with
+// Logger.Ctx or Event.Ctx pick them up; no extractor registration is needed.
+func ContextWithFields(ctx context.Context, fs ...Field) context.Context {
+	if len(fs) == 0 {
+		return ctx
+	}
+	prev := FieldsFromContext(ctx)
+	merged := make([]Field, 0, len(prev)+len(fs))
+	merged = append(append(merged, prev...), fs...)
+	return context.WithValue(ctx, ctxFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields stored by ContextWithFields, outermost
+// first. The returned slice must not be modified.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fs, _ := ctx.Value(ctxFieldsKey{}).([]Field)
+	return fs
+}
+
+// Ctx returns an Info event pre-populated with the fields stored in ctx via
+// ContextWithFields. For other levels use l.NewEvent(level).Ctx(ctx).
+func (l *Logger) Ctx(ctx context.Context) *Event { return getEvent(l, LevelInfo).Ctx(ctx) }
+
+// Ctx appends the fields stored in ctx via ContextWithFields.
+func (e *Event) Ctx(ctx context.Context) *Event {
+	e.fields = append(e.fields, FieldsFromContext(ctx)...)
+	return e
+}
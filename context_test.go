@@ -0,0 +1,43 @@
+package xlog
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextWithFields(t *testing.T) {
+	t.Parallel()
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	outer := ContextWithFields(context.Background(), Str("request_id", "r1"))
+	inner := ContextWithFields(outer, Str("user", "u1"))
+
+	logger.Ctx(outer).Str("op", "a").Msg("outer")
+	logger.Ctx(inner).Msg("inner")
+	logger.NewEvent(LevelWarn).Ctx(context.Background()).Msg("plain")
+
+	if got := FieldsFromContext(outer); len(got) != 1 {
+		t.Fatalf("nested call modified the outer context: %+v", got)
+	}
+
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	if len(ad.logs) != 3 {
+		t.Fatalf("expected 3 logs, got %d", len(ad.logs))
+	}
+	if e := ad.logs[0]; e.Level != LevelInfo || len(e.Fields) != 2 {
+		t.Fatalf("unexpected outer entry: %+v", e)
+	}
+	assertHasStr(t, ad.logs[0].Fields, "request_id", "r1")
+	assertHasStr(t, ad.logs[0].Fields, "op", "a")
+	assertHasStr(t, ad.logs[1].Fields, "request_id", "r1")
+	assertHasStr(t, ad.logs[1].Fields, "user", "u1")
+	if n := len(ad.logs[2].Fields); n != 0 {
+		t.Fatalf("empty context added %d fields", n)
+	}
+}
@@ -0,0 +1,29 @@
+package xlog
+
+import "sync/atomic"
+
+// LevelCounter counts emitted entries per level. It works with every adapter
+// because it is fed by the Logger's observer notifications.
+type LevelCounter struct {
+	counts [256]atomic.Uint64 // indexed by uint8(Level); covers every int8 value
+}
+
+// NewLevelCounterObserver returns a LevelCounter and the Observer to register
+// (e.g. via Builder.AddObserver) that feeds it.
+func NewLevelCounterObserver() (*LevelCounter, Observer) {
+	c := &LevelCounter{}
+	return c, c
+}
+
+// Count returns the number of entries observed at level.
+func (c *LevelCounter) Count(level Level) uint64 { return c.counts[uint8(level)].Load() }
+
+// Reset zeroes all counters.
+func (c *LevelCounter) Reset() {
+	for i := range c.counts {
+		c.counts[i].Store(0)
+	}
+}
+
+func (c *LevelCounter) OnEvent(e Entry)         { c.counts[uint8(e.Level)].Add(1) }
+func (c *LevelCounter) OnConfig(_ ConfigChange) {}
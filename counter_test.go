@@ -0,0 +1,37 @@
+package xlog
+
+import "testing"
+
+func TestLevelCounterObserver(t *testing.T) {
+	t.Parallel()
+
+	counter, obs := NewLevelCounterObserver()
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithMinLevel(LevelDebug).
+		AddObserver(obs).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Trace().Msg("filtered")
+	logger.Debug().Msg("d")
+	for i := 0; i < 3; i++ {
+		logger.Info().Msg("i")
+	}
+	logger.Error().Msg("e")
+	logger.With(Str("k", "v")).Error().Msg("child")
+
+	want := map[Level]uint64{LevelTrace: 0, LevelDebug: 1, LevelInfo: 3, LevelWarn: 0, LevelError: 2}
+	for l, n := range want {
+		if got := counter.Count(l); got != n {
+			t.Fatalf("Count(%v) = %d, want %d", l, got, n)
+		}
+	}
+
+	counter.Reset()
+	if got := counter.Count(LevelInfo); got != 0 {
+		t.Fatalf("Count after Reset = %d", got)
+	}
+}
@@ -0,0 +1,59 @@
+package xlog
+
+import "encoding/json"
+
+// Decimal adds v as an arbitrary-precision number, e.g. a money amount that
+// must not be rounded through float64. A valid JSON number is stored as a
+// json.Number so JSON encoders emit it verbatim and unquoted; anything else
+// falls back to a plain (quoted) string field.
+func (e *Event) Decimal(k, v string) *Event {
+	if !isJSONNumber(v) {
+		return e.Str(k, v)
+	}
+	// Not e.Any: json.Number is a Stringer and would be stored as a string.
+	e.fields = append(e.fields, Field{K: k, Kind: KindAny, Any: json.Number(v)})
+	return e
+}
+
+// isJSONNumber reports whether s matches the JSON number grammar:
+// -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+func isJSONNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		j := skipDigits(s, i+1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		j := skipDigits(s, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(s)
+}
+
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,59 @@
+package xlog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEventDecimal(t *testing.T) {
+	t.Parallel()
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().
+		Decimal("amount", "1234.5678901234567890").
+		Decimal("bad", "12,50").
+		Msg("payment")
+
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	fs := ad.logs[0].Fields
+	obj := map[string]any{}
+	for _, f := range fs {
+		switch f.Kind {
+		case KindAny:
+			obj[f.K] = f.Any
+		case KindString:
+			obj[f.K] = f.Str
+		}
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"amount":1234.5678901234567890,"bad":"12,50"}`
+	if string(out) != want {
+		t.Fatalf("got %s, want %s", out, want)
+	}
+}
+
+func TestIsJSONNumber(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"0", "-0", "1", "-12.50", "1e9", "1.5E-3", "1234.5678901234567890"}
+	invalid := []string{"", "-", "01", "1.", ".5", "1e", "+1", "NaN", "1_000", "12,50"}
+	for _, s := range valid {
+		if !isJSONNumber(s) {
+			t.Errorf("isJSONNumber(%q) = false", s)
+		}
+	}
+	for _, s := range invalid {
+		if isJSONNumber(s) {
+			t.Errorf("isJSONNumber(%q) = true", s)
+		}
+	}
+}
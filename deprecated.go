@@ -0,0 +1,28 @@
+package xlog
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// deprecatedSites records call sites that already produced a warning.
+var deprecatedSites sync.Map // map[uintptr]struct{}
+
+// Deprecated logs a warning through the global logger the first time each call
+// site reaches it. Call it from the body of the deprecated function: the site is
+// the caller of that function, reported as a "caller" file:line field next to
+// deprecated=true. Later calls from the same site are silent.
+func Deprecated(msg string, fields ...Field) {
+	pc, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return
+	}
+	if _, seen := deprecatedSites.LoadOrStore(pc, struct{}{}); seen {
+		return
+	}
+	fs := make([]Field, 0, len(fields)+2)
+	fs = append(fs, Bool("deprecated", true), Str("caller", file+":"+strconv.Itoa(line)))
+	fs = append(fs, fields...)
+	L().LogAt(LevelWarn, msg, fs...)
+}
@@ -0,0 +1,52 @@
+package xlog
+
+import (
+	"strings"
+	"testing"
+)
+
+func oldAPI() { Deprecated("oldAPI is deprecated; use newAPI", Str("replacement", "newAPI")) }
+
+// Not parallel: mutates the global logger.
+func TestDeprecated_DedupesPerCallSite(t *testing.T) {
+	orig := L()
+	defer SetGlobal(orig)
+	t.Cleanup(deprecatedSites.Clear) // sites are process-wide; reset for -count>1
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	SetGlobal(logger)
+
+	for i := 0; i < 3; i++ {
+		oldAPI() // site A, reached three times
+	}
+	oldAPI() // site B
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 2 {
+		t.Fatalf("expected 2 warnings (one per site), got %d", len(adapter.logs))
+	}
+	for _, e := range adapter.logs {
+		if e.Level != LevelWarn {
+			t.Fatalf("level mismatch: %v", e.Level)
+		}
+		var deprecated bool
+		var caller string
+		for _, f := range e.Fields {
+			switch f.K {
+			case "deprecated":
+				deprecated = f.Bool
+			case "caller":
+				caller = f.Str
+			}
+		}
+		if !deprecated || !strings.Contains(caller, "deprecated_test.go:") {
+			t.Fatalf("unexpected fields: %+v", e.Fields)
+		}
+		assertHasStr(t, e.Fields, "replacement", "newAPI")
+	}
+}
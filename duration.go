@@ -0,0 +1,76 @@
+package xlog
+
+import (
+	"strconv"
+	"time"
+)
+
+// DurISO adds d as an ISO-8601 duration string (e.g. "PT1H30M", "PT1.5S").
+func (e *Event) DurISO(k string, d time.Duration) *Event {
+	return e.Str(k, FormatISODuration(d))
+}
+
+// FormatISODuration formats d as an ISO-8601 duration using hours, minutes and
+// (fractional) seconds: 90m -> "PT1H30M", 1500ms -> "PT1.5S", 0 -> "PT0S".
+// Negative durations get a leading '-'.
+func FormatISODuration(d time.Duration) string {
+	var buf [32]byte
+	b := buf[:0]
+	u := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		u = -u // two's complement; also correct for math.MinInt64
+	}
+	b = append(b, 'P', 'T')
+
+	h := u / uint64(time.Hour)
+	u -= h * uint64(time.Hour)
+	m := u / uint64(time.Minute)
+	u -= m * uint64(time.Minute)
+	s := u / uint64(time.Second)
+	ns := u - s*uint64(time.Second)
+
+	if h > 0 {
+		b = strconv.AppendUint(b, h, 10)
+		b = append(b, 'H')
+	}
+	if m > 0 {
+		b = strconv.AppendUint(b, m, 10)
+		b = append(b, 'M')
+	}
+	if s > 0 || ns > 0 || (h == 0 && m == 0) {
+		b = strconv.AppendUint(b, s, 10)
+		if ns > 0 {
+			var frac [9]byte
+			for i := len(frac) - 1; i >= 0; i-- {
+				frac[i] = byte('0' + ns%10)
+				ns /= 10
+			}
+			n := len(frac)
+			for frac[n-1] == '0' {
+				n--
+			}
+			b = append(b, '.')
+			b = append(b, frac[:n]...)
+		}
+		b = append(b, 'S')
+	}
+	return string(b)
+}
+
+// DurBoth adds d twice: under k as integer nanoseconds and under k+"_human"
+// as its Go string form (1500ms -> 1500000000 and "1.5s"), so dashboards can
+// both aggregate and display it whatever the adapter's duration encoding.
+func (e *Event) DurBoth(k string, d time.Duration) *Event {
+	return e.Int64(k, int64(d)).Str(k+"_human", d.String())
+}
+
+// Rate adds count/over as a per-second Float64 under k plus the raw count
+// under k+"_count". A zero or negative over yields a rate of 0.
+func (e *Event) Rate(k string, count int64, over time.Duration) *Event {
+	var r float64
+	if over > 0 {
+		r = float64(count) / over.Seconds()
+	}
+	return e.Float64(k, r).Int64(k+"_count", count)
+}
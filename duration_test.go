@@ -0,0 +1,87 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatISODuration(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{90 * time.Minute, "PT1H30M"},
+		{1500 * time.Millisecond, "PT1.5S"},
+		{2*time.Hour + 3*time.Second + 40*time.Microsecond, "PT2H3.00004S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{-45 * time.Second, "-PT45S"},
+	}
+	for _, c := range cases {
+		if got := FormatISODuration(c.d); got != c.want {
+			t.Fatalf("FormatISODuration(%s) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
+
+func TestEventDurISO(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().DurISO("timeout", 90*time.Minute).Msg("configured")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	assertHasStr(t, adapter.logs[0].Fields, "timeout", "PT1H30M")
+}
+
+func TestEventDurBoth(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().DurBoth("took", 1500*time.Millisecond).Msg("done")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	assertHasInt64(t, adapter.logs[0].Fields, "took", 1500000000)
+	assertHasStr(t, adapter.logs[0].Fields, "took_human", "1.5s")
+}
+
+func TestEventRate(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().
+		Rate("rps", 100, time.Second).
+		Rate("frac", 3, 2*time.Second).
+		Rate("none", 5, 0).
+		Msg("throughput")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	want := map[string]float64{"rps": 100, "frac": 1.5, "none": 0}
+	for _, f := range adapter.logs[0].Fields {
+		if f.Kind == KindFloat64 && f.Float64 != want[f.K] {
+			t.Fatalf("%s = %v, want %v", f.K, f.Float64, want[f.K])
+		}
+	}
+	assertHasInt64(t, adapter.logs[0].Fields, "rps_count", 100)
+	assertHasInt64(t, adapter.logs[0].Fields, "frac_count", 3)
+	if n := len(adapter.logs[0].Fields); n != 6 {
+		t.Fatalf("expected 6 fields, got %d", n)
+	}
+}
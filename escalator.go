@@ -0,0 +1,54 @@
+package xlog
+
+import (
+	"sync"
+	"time"
+)
+
+// ErrorRateEscalator is an Observer that calls onTrip when threshold entries
+// at LevelError or above are seen within a sliding window, e.g. to switch
+// sampling off or raise verbosity during an incident. Time is taken from
+// Entry.At, i.e. the logger's clock, so frozen clocks drive it in tests. After
+// tripping the window starts empty, so onTrip fires again only after another
+// threshold errors.
+type ErrorRateEscalator struct {
+	threshold int
+	window    time.Duration
+	onTrip    func()
+
+	mu    sync.Mutex
+	times []time.Time // error timestamps within the window, oldest first
+}
+
+// NewErrorRateEscalator returns an escalator tripping on threshold errors
+// within window. Register it with Builder.AddObserverAt(esc, LevelError) so
+// other entries skip it entirely. threshold <= 0 never trips.
+func NewErrorRateEscalator(threshold int, window time.Duration, onTrip func()) *ErrorRateEscalator {
+	return &ErrorRateEscalator{threshold: threshold, window: window, onTrip: onTrip}
+}
+
+// OnEvent implements Observer.
+func (r *ErrorRateEscalator) OnEvent(e Entry) {
+	if e.Level < LevelError || r.threshold <= 0 {
+		return
+	}
+	r.mu.Lock()
+	cut := 0
+	for cut < len(r.times) && e.At.Sub(r.times[cut]) >= r.window {
+		cut++
+	}
+	r.times = append(r.times[cut:], e.At)
+	trip := len(r.times) >= r.threshold
+	if trip {
+		r.times = r.times[:0]
+	}
+	r.mu.Unlock()
+
+	// Outside the lock: onTrip may log or reconfigure the logger.
+	if trip {
+		r.onTrip()
+	}
+}
+
+// OnConfig implements Observer.
+func (r *ErrorRateEscalator) OnConfig(ConfigChange) {}
@@ -0,0 +1,49 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorRateEscalator(t *testing.T) {
+	t.Parallel()
+
+	clk := newStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	trips := 0
+	esc := NewErrorRateEscalator(3, time.Minute, func() { trips++ })
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithClock(clk).
+		AddObserverAt(esc, LevelError).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	// Two errors, then the window slides past them.
+	logger.Error().Msg("e1")
+	logger.Error().Msg("e2")
+	logger.Warn().Msg("not counted")
+	clk.Advance(time.Minute)
+	logger.Error().Msg("e3")
+	if trips != 0 {
+		t.Fatalf("tripped on errors spread beyond the window (%d)", trips)
+	}
+
+	clk.Advance(10 * time.Second)
+	logger.Error().Msg("e4")
+	logger.Fatal().Msg("e5")
+	if trips != 1 {
+		t.Fatalf("trips = %d after 3 errors within the window, want 1", trips)
+	}
+
+	logger.Error().Msg("e6")
+	logger.Error().Msg("e7")
+	if trips != 1 {
+		t.Fatalf("trips = %d, want the window to restart after tripping", trips)
+	}
+	logger.Error().Msg("e8")
+	if trips != 2 {
+		t.Fatalf("trips = %d, want 2", trips)
+	}
+}
@@ -1,6 +1,11 @@
 package xlog
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,17 +17,29 @@ type Event struct {
 	l      *Logger
 	level  Level
 	fields []Field
+	groups []eventGroup // open OpenGroup scopes, innermost last
+	skip   bool         // set by Every; Msg discards the event
 }
 
 var eventPool = sync.Pool{
 	New: func() any { return &Event{fields: make([]Field, 0, 8)} },
 }
 
+// NewEvent returns a pooled Event at level, the same object the per-level
+// helpers (Info, Debug, ...) return. Fields may be added across several steps
+// (e.g. common fields first, then branch-specific ones), but the caller MUST
+// finish with exactly one Msg, which emits the event and returns it to the
+// pool. The Event must not be used after Msg.
+func (l *Logger) NewEvent(level Level) *Event { return getEvent(l, level) }
+
 func getEvent(l *Logger, level Level) *Event {
+	l = l.orGlobal()
 	ev := eventPool.Get().(*Event)
 	ev.l = l
 	ev.level = level
 	ev.fields = ev.fields[:0]
+	ev.groups = ev.groups[:0]
+	ev.skip = false
 	return ev
 }
 
@@ -43,8 +60,29 @@ func (e *Event) Str(k, v string) *Event {
 	return e
 }
 
+// StrOmitEmpty adds v under k only when v is non-empty, keeping optional
+// string fields off the line entirely otherwise.
+func (e *Event) StrOmitEmpty(k, v string) *Event {
+	if v == "" {
+		return e
+	}
+	return e.Str(k, v)
+}
+
 func (e *Event) Int(k string, v int) *Event { return e.Int64(k, int64(v)) }
 
+// Narrower integer helpers: signed values are sign-extended into KindInt64 and
+// unsigned ones zero-extended into KindUint64, so no cast can flip a sign.
+
+func (e *Event) Int8(k string, v int8) *Event   { return e.Int64(k, int64(v)) }
+func (e *Event) Int16(k string, v int16) *Event { return e.Int64(k, int64(v)) }
+func (e *Event) Int32(k string, v int32) *Event { return e.Int64(k, int64(v)) }
+
+func (e *Event) Uint(k string, v uint) *Event     { return e.Uint64(k, uint64(v)) }
+func (e *Event) Uint8(k string, v uint8) *Event   { return e.Uint64(k, uint64(v)) }
+func (e *Event) Uint16(k string, v uint16) *Event { return e.Uint64(k, uint64(v)) }
+func (e *Event) Uint32(k string, v uint32) *Event { return e.Uint64(k, uint64(v)) }
+
 func (e *Event) Int64(k string, v int64) *Event {
 	e.fields = append(e.fields, Field{K: k, Kind: KindInt64, Int64: v})
 	return e
@@ -60,6 +98,14 @@ func (e *Event) Float64(k string, v float64) *Event {
 	return e
 }
 
+// Float32 adds v keeping its float32 identity, so encoders print the shortest
+// 32-bit representation (0.1) rather than the widened float64 digits
+// (0.10000000149011612).
+func (e *Event) Float32(k string, v float32) *Event {
+	e.fields = append(e.fields, Float32(k, v))
+	return e
+}
+
 func (e *Event) Bool(k string, v bool) *Event {
 	e.fields = append(e.fields, Field{K: k, Kind: KindBool, Bool: v})
 	return e
@@ -75,26 +121,165 @@ func (e *Event) Time(k string, v time.Time) *Event {
 	return e
 }
 
+// TimeNow adds the current time from the logger's clock under k, so frozen
+// or offset clocks apply to the field just as they do to the entry timestamp.
+func (e *Event) TimeNow(k string) *Event {
+	if e.l == nil {
+		return e
+	}
+	return e.Time(k, e.l.clock.Now())
+}
+
 func (e *Event) Bytes(k string, v []byte) *Event {
 	e.fields = append(e.fields, Field{K: k, Kind: KindBytes, Bytes: v})
 	return e
 }
 
+// FieldsError is implemented by structured (domain) errors that carry their own
+// fields, e.g. an error code.
+type FieldsError interface {
+	error
+	Fields() []Field
+}
+
+// Err adds err under "error". When err, or an error it wraps, is a
+// FieldsError, its fields are added too with keys prefixed by "error."
+// (e.g. "error.code").
 func (e *Event) Err(err error) *Event {
 	if err == nil {
 		return e
 	}
 	e.fields = append(e.fields, Field{K: "error", Kind: KindError, Err: err})
+	var fe FieldsError
+	if errors.As(err, &fe) {
+		for _, f := range fe.Fields() {
+			f.K = "error." + f.K
+			e.fields = append(e.fields, f)
+		}
+	}
 	return e
 }
 
+// Any adds v under k. Errors and fmt.Stringers are converted up front to
+// KindError and KindString (errors win when v is both), so every adapter
+// renders them alike without reflection. A time.Duration becomes KindDuration,
+// as with Dur, so backends keep their own duration encoding. Stringers that
+// are also json.Marshalers (time.Time, ...) keep KindAny and their JSON form;
+// nil values stay KindAny.
 func (e *Event) Any(k string, v any) *Event {
+	switch x := v.(type) {
+	case time.Duration:
+		e.fields = append(e.fields, Field{K: k, Kind: KindDuration, Dur: x})
+		return e
+	case error:
+		if !isNilValue(x) {
+			e.fields = append(e.fields, Field{K: k, Kind: KindError, Err: x})
+			return e
+		}
+	case json.Marshaler:
+	case fmt.Stringer:
+		if !isNilValue(x) {
+			e.fields = append(e.fields, Field{K: k, Kind: KindString, Str: x.String()})
+			return e
+		}
+	}
 	e.fields = append(e.fields, Field{K: k, Kind: KindAny, Any: v})
 	return e
 }
 
-// Msg terminates the builder and emits the event.
+// isNilValue reports whether v holds a typed nil, on which String or Error
+// would likely panic.
+func isNilValue(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Interface, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// lazyAny defers construction of a KindAny value until the entry is emitted.
+type lazyAny func() any
+
+// Anyf adds a KindAny field whose value is produced by fn only if the event is
+// actually emitted. Use it for values that are expensive to build.
+func (e *Event) Anyf(k string, fn func() any) *Event {
+	e.fields = append(e.fields, Field{K: k, Kind: KindAny, Any: lazyAny(fn)})
+	return e
+}
+
+// Merge appends the fields bound to other (see Logger.BoundFields) to the
+// event, e.g. to include a sub-component logger's context in a single entry.
+// Keys lose other's KeyPrefix; the event's own logger applies its prefix.
+func (e *Event) Merge(other *Logger) *Event {
+	if other == nil {
+		return e
+	}
+	for _, f := range other.bound {
+		f.K = strings.TrimPrefix(f.K, other.prefix)
+		e.fields = append(e.fields, f)
+	}
+	return e
+}
+
+// Enabled reports whether Msg would emit this event. Use it to skip
+// computing expensive fields for events that would be dropped.
+func (e *Event) Enabled() bool { return e.l != nil && !e.skip && e.l.Enabled(e.level) }
+
+// AtLevel runs fn to add fields only when the event is at maxLevel or more
+// verbose (and enabled), e.g. detail that should appear in debug output but
+// not cost anything when the same code path logs at info:
+//
+//	l.NewEvent(level).Str("op", op).AtLevel(xlog.LevelDebug, func(e *xlog.Event) {
+//		e.Any("debug_detail", dumpState())
+//	}).Msg("step")
+func (e *Event) AtLevel(maxLevel Level, fn func(*Event)) *Event {
+	if e.level <= maxLevel && e.Enabled() {
+		fn(e)
+	}
+	return e
+}
+
+// Snapshot returns a copy of the fields accumulated so far.
+// The copy is safe to retain after Msg.
+func (e *Event) Snapshot() []Field { return copyFields(nil, e.fields) }
+
+// Msg terminates the builder and emits the event. The Event returns to the
+// pool and must not be used after Msg (or Emit).
 func (e *Event) Msg(msg string) {
-	e.l.emit(e.level, msg, e.fields)
+	if e.l == nil {
+		return
+	}
+	if !e.skip {
+		e.closeGroups()
+		e.l.emit(e.level, msg, e.fields)
+	}
+	e.putBack()
+}
+
+// Emit is like Msg but also returns the Entry as logged: level, timestamp,
+// message and the logger's bound fields followed by the event fields. It
+// returns the zero Entry when the event was filtered out or sampled away.
+// The returned Entry is a copy and safe to retain.
+func (e *Event) Emit(msg string) Entry {
+	if e.l == nil {
+		return Entry{}
+	}
+	l, level := e.l, e.level
+	if e.skip {
+		e.putBack()
+		return Entry{}
+	}
+	e.closeGroups()
+	at, fields, ok := l.emit(level, msg, e.fields)
+	var out Entry
+	if ok {
+		// fields may alias e.fields (borrowing adapters), so copy before putBack.
+		out = Entry{Level: level, Message: msg, At: at}
+		if n := len(l.bound) + len(fields); n > 0 {
+			out.Fields = append(append(make([]Field, 0, n), l.bound...), fields...)
+		}
+	}
 	e.putBack()
+	return out
 }
@@ -0,0 +1,380 @@
+package xlog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xclock/adapter/frozen"
+)
+
+func TestNewEvent_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	ev := logger.NewEvent(LevelInfo)
+	ev.Str("tenant", "t-1")
+	snap := ev.Snapshot()
+	ev.Int("attempt", 2)
+
+	if len(snap) != 1 {
+		t.Fatalf("snapshot should be a point-in-time copy, got %+v", snap)
+	}
+	assertHasStr(t, ev.Snapshot(), "tenant", "t-1")
+	assertHasInt64(t, ev.Snapshot(), "attempt", 2)
+
+	ev.Msg("retry")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	assertHasStr(t, adapter.logs[0].Fields, "tenant", "t-1")
+	assertHasInt64(t, adapter.logs[0].Fields, "attempt", 2)
+	assertHasStr(t, snap, "tenant", "t-1")
+}
+
+func TestAnyf_ResolvedOnlyWhenEmitted(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	calls := 0
+	build := func() any {
+		calls++
+		return map[string]int{"rows": 3}
+	}
+
+	logger.Debug().Anyf("detail", build).Msg("filtered")
+	if calls != 0 {
+		t.Fatalf("closure called %d times for a dropped entry", calls)
+	}
+
+	logger.Info().Anyf("detail", build).Msg("kept")
+	if calls != 1 {
+		t.Fatalf("closure called %d times, want 1", calls)
+	}
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	f := adapter.logs[0].Fields[0]
+	if m, ok := f.Any.(map[string]int); !ok || m["rows"] != 3 {
+		t.Fatalf("adapter received unresolved value %#v", f.Any)
+	}
+}
+
+func TestEventMerge_AppendsOtherLoggersBoundFields(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	db, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithFields(Str("component", "db")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	db = db.With(Str("pool", "primary"))
+
+	if got := db.BoundFields(); len(got) != 2 || got[0].K != "component" || got[1].K != "pool" {
+		t.Fatalf("BoundFields = %+v", got)
+	}
+
+	logger.Info().Str("op", "query").Merge(db).Merge(nil).Msg("slow query")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	fs := adapter.logs[0].Fields
+	assertHasStr(t, fs, "op", "query")
+	assertHasStr(t, fs, "component", "db")
+	assertHasStr(t, fs, "pool", "primary")
+}
+
+func TestEventMerge_StripsOtherLoggersKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).WithKeyPrefix("app_").Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	db, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithKeyPrefix("db_").
+		WithFields(Str("pool", "primary")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	db = db.With(Str("shard", "s1"))
+
+	logger.Info().Merge(db).Msg("query")
+
+	adapter := logger.ad.(*stubAdapter)
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	fs := adapter.logs[0].Fields
+	if len(fs) != 2 {
+		t.Fatalf("fields = %+v, want 2", fs)
+	}
+	assertHasStr(t, fs, "app_pool", "primary")
+	assertHasStr(t, fs, "app_shard", "s1")
+}
+
+func TestEventEmit_ReturnsLoggedEntry(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithClock(frozen.New(fixed)).
+		WithFields(Str("svc", "api")).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	got := logger.Warn().Int("attempt", 3).Emit("retrying")
+
+	adapter = logger.ad.(*stubAdapter) // WithFields bound a derived adapter
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(adapter.logs))
+	}
+	want := adapter.logs[0]
+	if got.Level != want.Level || got.Message != want.Msg || !got.At.Equal(want.At) {
+		t.Fatalf("Emit = %+v, adapter got %+v", got, want)
+	}
+	if !reflect.DeepEqual(got.Fields, want.Fields) {
+		t.Fatalf("Emit fields = %+v, adapter got %+v", got.Fields, want.Fields)
+	}
+
+	if e := logger.Debug().Str("k", "v").Emit("filtered"); e.Message != "" || e.Fields != nil {
+		t.Fatalf("filtered Emit = %+v, want zero Entry", e)
+	}
+}
+
+func TestEventFloat32_KeepsShortestForm(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Float32("ratio", 0.1).Msg("f32")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	f := adapter.logs[0].Fields[0]
+	if f.Kind != KindFloat32 {
+		t.Fatalf("kind = %v, want KindFloat32", f.Kind)
+	}
+	if got := strconv.FormatFloat(f.Float64, 'g', -1, 32); got != "0.1" {
+		t.Fatalf("32-bit format = %q, want 0.1", got)
+	}
+}
+
+func TestEventStrOmitEmpty(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().StrOmitEmpty("user", "").StrOmitEmpty("region", "eu").Str("note", "").Msg("omit")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	fs := adapter.logs[0].Fields
+	if len(fs) != 2 {
+		t.Fatalf("expected region and note only, got %+v", fs)
+	}
+	assertHasStr(t, fs, "region", "eu")
+	assertHasStr(t, fs, "note", "") // plain Str keeps empty values
+}
+
+type testStringer struct{ s string }
+
+func (t *testStringer) String() string { return t.s }
+
+func TestEventAny_ErrorAndStringer(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	boom := errors.New("boom")
+	var nilStringer *testStringer
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger.Info().
+		Any("err", boom).
+		Any("who", &testStringer{s: "ada"}).
+		Any("nilptr", nilStringer).
+		Any("nil", nil).
+		Any("at", at).
+		Any("took", 1500*time.Millisecond).
+		Msg("any")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	fs := adapter.logs[0].Fields
+	if f := fs[0]; f.Kind != KindError || f.Err != boom {
+		t.Errorf("error value: %+v, want KindError like Err", f)
+	}
+	assertHasStr(t, fs, "who", "ada")
+	if f := fs[len(fs)-1]; f.Kind != KindDuration || f.Dur != 1500*time.Millisecond {
+		t.Errorf("duration value: %+v, want KindDuration like Dur", f)
+	}
+	for _, f := range fs[2 : len(fs)-1] {
+		if f.Kind != KindAny {
+			t.Errorf("%s: kind %d, want KindAny", f.K, f.Kind)
+		}
+	}
+}
+
+func TestEventAtLevel(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	calls := 0
+	step := func(level Level) {
+		logger.NewEvent(level).Str("op", "sync").AtLevel(LevelDebug, func(e *Event) {
+			calls++
+			e.Str("debug_detail", "state")
+		}).Msg("step")
+	}
+	step(LevelDebug)
+	step(LevelInfo)
+	step(LevelTrace) // below the min level: fn must not run either
+
+	if calls != 1 || len(adapter.logs) != 2 {
+		t.Fatalf("calls = %d, logs = %d; want 1 and 2", calls, len(adapter.logs))
+	}
+	assertHasStr(t, adapter.logs[0].Fields, "debug_detail", "state")
+	if info := adapter.logs[1].Fields; len(info) != 1 {
+		t.Fatalf("info entry should only carry op, got %+v", info)
+	}
+}
+
+type codedError struct {
+	code   string
+	status int
+}
+
+func (e *codedError) Error() string { return "coded " + e.code }
+
+func (e *codedError) Fields() []Field {
+	return []Field{Str("code", e.code), Int64("status", int64(e.status))}
+}
+
+func TestEventErr_FlattensFieldsError(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	domain := &codedError{code: "E42", status: 409}
+	logger.Error().Err(fmt.Errorf("save order: %w", domain)).Msg("failed")
+	logger.Error().Err(errors.New("plain")).Msg("failed")
+
+	fs := adapter.logs[0].Fields
+	if len(fs) != 3 || fs[0].K != "error" || fs[0].Err.Error() != "save order: coded E42" {
+		t.Fatalf("fields = %+v", fs)
+	}
+	assertHasStr(t, fs, "error.code", "E42")
+	assertHasInt64(t, fs, "error.status", 409)
+	if plain := adapter.logs[1].Fields; len(plain) != 1 {
+		t.Fatalf("plain error should add only the error field, got %+v", plain)
+	}
+}
+
+func TestEventTimeNow_UsesLoggerClock(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithClock(frozen.New(at)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().TimeNow("seen_at").Msg("stamped")
+
+	f := adapter.logs[0].Fields[0]
+	if f.K != "seen_at" || f.Kind != KindTime || !f.Time.Equal(at) {
+		t.Fatalf("field = %+v, want seen_at=%v", f, at)
+	}
+}
+
+func TestEventNarrowIntegers(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().
+		Int8("i8", math.MinInt8).
+		Int16("i16", -300).
+		Int32("i32", math.MinInt32).
+		Uint("u", math.MaxUint).
+		Uint8("u8", math.MaxUint8).
+		Uint16("u16", math.MaxUint16).
+		Uint32("u32", math.MaxUint32).
+		Msg("ints")
+
+	fs := adapter.logs[0].Fields
+	assertHasInt64(t, fs, "i8", math.MinInt8)
+	assertHasInt64(t, fs, "i16", -300)
+	assertHasInt64(t, fs, "i32", math.MinInt32)
+	for _, want := range []struct {
+		k string
+		v uint64
+	}{{"u", math.MaxUint}, {"u8", math.MaxUint8}, {"u16", math.MaxUint16}, {"u32", math.MaxUint32}} {
+		found := false
+		for _, f := range fs {
+			found = found || (f.K == want.k && f.Kind == KindUint64 && f.Uint64 == want.v)
+		}
+		if !found {
+			t.Errorf("missing uint64 field %s=%d in %+v", want.k, want.v, fs)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package xlog
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// everySites holds one call counter per Event.Every call site.
+var everySites sync.Map // map[uintptr]*atomic.Uint64
+
+// Every samples the event per call site: only the 1st, (n+1)th, (2n+1)th, ...
+// event built at this source location is emitted; the rest are discarded by
+// Msg (and report Enabled() == false, so later fields can be skipped). The
+// counter is shared by all loggers and goroutines. n <= 1 keeps every event.
+//
+//	l.Debug().Every(100).Int("depth", q.Len()).Msg("queue depth")
+func (e *Event) Every(n int) *Event {
+	if n <= 1 {
+		return e
+	}
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return e
+	}
+	c, found := everySites.Load(pc)
+	if !found {
+		c, _ = everySites.LoadOrStore(pc, new(atomic.Uint64))
+	}
+	if (c.(*atomic.Uint64).Add(1)-1)%uint64(n) != 0 {
+		e.skip = true
+	}
+	return e
+}
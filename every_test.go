@@ -0,0 +1,45 @@
+package xlog
+
+import "testing"
+
+func TestEventEvery_PerCallSite(t *testing.T) {
+	t.Parallel()
+	t.Cleanup(everySites.Clear) // counters are process-wide; reset for -count>1
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	enabled := 0
+	for i := 0; i < 10; i++ {
+		ev := logger.Info().Every(3).Int("i", i)
+		if ev.Enabled() {
+			enabled++
+		}
+		ev.Msg("sampled")
+		logger.Info().Every(5).Msg("other site") // independent counter
+	}
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	var got []int64
+	others := 0
+	for _, e := range adapter.logs {
+		if e.Msg == "other site" {
+			others++
+			continue
+		}
+		got = append(got, e.Fields[0].Int64)
+	}
+	if len(got) != 4 || got[0] != 0 || got[1] != 3 || got[2] != 6 || got[3] != 9 {
+		t.Fatalf("emitted i = %v, want [0 3 6 9]", got)
+	}
+	if enabled != 4 {
+		t.Fatalf("Enabled reported true %d times, want 4", enabled)
+	}
+	if others != 2 {
+		t.Fatalf("second call site emitted %d, want 2", others)
+	}
+}
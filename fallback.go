@@ -0,0 +1,73 @@
+package xlog
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// adapterErrorLogger is an optional interface adapters can implement to report
+// write failures, which FallbackAdapter uses to decide when to fall back.
+// LogE behaves like Log but returns the error from writing the entry.
+type adapterErrorLogger interface {
+	LogE(level Level, msg string, at time.Time, fields []Field) error
+}
+
+// FallbackAdapter returns an Adapter that writes each entry to primary and,
+// when primary reports a write error through an optional
+// LogE(level, msg, at, fields) error method, writes the same entry to fallback
+// (e.g. a stderr adapter). Primaries without LogE never fall back.
+func FallbackAdapter(primary, fallback Adapter) Adapter {
+	return &fallbackAdapter{primary: primary, fallback: fallback}
+}
+
+type fallbackAdapter struct {
+	primary  Adapter
+	fallback Adapter
+}
+
+func (a *fallbackAdapter) With(fs []Field) Adapter {
+	return &fallbackAdapter{primary: a.primary.With(fs), fallback: a.fallback.With(fs)}
+}
+
+func (a *fallbackAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
+	_ = a.LogE(level, msg, at, fields)
+}
+
+// LogE reports the fallback's error when both adapters fail, so fallback
+// adapters can be chained.
+func (a *fallbackAdapter) LogE(level Level, msg string, at time.Time, fields []Field) error {
+	p, ok := a.primary.(adapterErrorLogger)
+	if !ok {
+		a.primary.Log(level, msg, at, fields)
+		return nil
+	}
+	if p.LogE(level, msg, at, fields) == nil {
+		return nil
+	}
+	if f, ok := a.fallback.(adapterErrorLogger); ok {
+		return f.LogE(level, msg, at, fields)
+	}
+	a.fallback.Log(level, msg, at, fields)
+	return nil
+}
+
+// SetMinLevel forwards min-level configuration to both adapters when supported.
+func (a *fallbackAdapter) SetMinLevel(min Level) {
+	for _, ad := range [2]Adapter{a.primary, a.fallback} {
+		if ls, ok := ad.(adapterLevelSetter); ok {
+			ls.SetMinLevel(min)
+		}
+	}
+}
+
+// Close closes both adapters that implement io.Closer.
+func (a *fallbackAdapter) Close() error {
+	var errs []error
+	for _, ad := range [2]Adapter{a.primary, a.fallback} {
+		if c, ok := ad.(io.Closer); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
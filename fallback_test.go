@@ -0,0 +1,51 @@
+package xlog
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingAdapter reports a write error for every entry while failing is set.
+type failingAdapter struct {
+	failing bool
+	calls   int
+}
+
+func (a *failingAdapter) With(_ []Field) Adapter                          { return a }
+func (a *failingAdapter) Log(l Level, m string, at time.Time, fs []Field) { _ = a.LogE(l, m, at, fs) }
+func (a *failingAdapter) LogE(_ Level, _ string, _ time.Time, _ []Field) error {
+	a.calls++
+	if a.failing {
+		return errors.New("sink unavailable")
+	}
+	return nil
+}
+
+func TestFallbackAdapter(t *testing.T) {
+	t.Parallel()
+
+	primary := &failingAdapter{failing: true}
+	fallback := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(FallbackAdapter(primary, fallback)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Warn().Str("k", "v").Msg("while down")
+	primary.failing = false
+	logger.Info().Msg("recovered")
+
+	if primary.calls != 2 {
+		t.Fatalf("primary calls = %d, want 2", primary.calls)
+	}
+	fallback.mu.Lock()
+	defer fallback.mu.Unlock()
+	if len(fallback.logs) != 1 {
+		t.Fatalf("fallback got %d entries, want 1", len(fallback.logs))
+	}
+	if e := fallback.logs[0]; e.Level != LevelWarn || e.Msg != "while down" {
+		t.Fatalf("unexpected fallback entry: %+v", e)
+	}
+	assertHasStr(t, fallback.logs[0].Fields, "k", "v")
+}
@@ -18,6 +18,7 @@ const (
 	KindError
 	KindBytes
 	KindAny
+	KindFloat32 // stored widened in Float64; encoders format it with 32-bit precision
 )
 
 // Field is a typed key/value pair for structured logging.
@@ -42,6 +43,7 @@ func Str(k, v string) Field             { return Field{K: k, Kind: KindString, S
 func Int64(k string, v int64) Field     { return Field{K: k, Kind: KindInt64, Int64: v} }
 func Uint64(k string, v uint64) Field   { return Field{K: k, Kind: KindUint64, Uint64: v} }
 func Float64(k string, v float64) Field { return Field{K: k, Kind: KindFloat64, Float64: v} }
+func Float32(k string, v float32) Field { return Field{K: k, Kind: KindFloat32, Float64: float64(v)} }
 func Bool(k string, v bool) Field       { return Field{K: k, Kind: KindBool, Bool: v} }
 func Dur(k string, v time.Duration) Field {
 	return Field{K: k, Kind: KindDuration, Dur: v}
@@ -50,3 +52,15 @@ func Time(k string, v time.Time) Field { return Field{K: k, Kind: KindTime, Time
 func Err(k string, e error) Field      { return Field{K: k, Kind: KindError, Err: e} }
 func Bytes(k string, b []byte) Field   { return Field{K: k, Kind: KindBytes, Bytes: b} }
 func Any(k string, v any) Field        { return Field{K: k, Kind: KindAny, Any: v} }
+
+// copyFields appends src to dst, allocating a right-sized slice when dst is nil.
+// It returns dst unchanged when src is empty.
+func copyFields(dst, src []Field) []Field {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make([]Field, 0, len(src))
+	}
+	return append(dst, src...)
+}
@@ -0,0 +1,162 @@
+package xlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format selects the rendering produced by FormatEntry.
+type Format uint8
+
+const (
+	FormatJSON   Format = iota + 1 // {"ts":...,"level":...,"msg":...,<fields>}
+	FormatLogfmt                   // ts=... level=... msg=... k=v
+)
+
+// FormatEntry renders e as a single line (without a trailing newline), e.g.
+// for an observer forwarding entries to a webhook. Keys appear as ts, level,
+// msg and then the fields in order. Durations use their Go string form, times
+// RFC3339Nano in UTC and errors their message; nil errors are omitted. An
+// unknown format renders as JSON.
+func FormatEntry(e Entry, format Format) string {
+	if format == FormatLogfmt {
+		return formatLogfmt(e)
+	}
+	return formatJSON(e)
+}
+
+func formatJSON(e Entry) string {
+	var b strings.Builder
+	b.WriteString(`{"ts":`)
+	b.WriteString(jsonString(e.At.UTC().Format(time.RFC3339Nano)))
+	b.WriteString(`,"level":`)
+	b.WriteString(jsonString(e.Level.String()))
+	b.WriteString(`,"msg":`)
+	b.WriteString(jsonString(e.Message))
+	for i := range e.Fields {
+		f := &e.Fields[i]
+		if f.Kind == KindError && f.Err == nil {
+			continue
+		}
+		b.WriteByte(',')
+		b.WriteString(jsonString(f.K))
+		b.WriteByte(':')
+		b.WriteString(jsonValue(f))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func jsonValue(f *Field) string {
+	switch f.Kind {
+	case KindString:
+		return jsonString(f.Str)
+	case KindInt64:
+		return strconv.FormatInt(f.Int64, 10)
+	case KindUint64:
+		return strconv.FormatUint(f.Uint64, 10)
+	case KindFloat64:
+		return jsonFloat(f.Float64, 64)
+	case KindFloat32:
+		return jsonFloat(f.Float64, 32)
+	case KindBool:
+		return strconv.FormatBool(f.Bool)
+	case KindDuration:
+		return jsonString(f.Dur.String())
+	case KindTime:
+		return jsonString(f.Time.UTC().Format(time.RFC3339Nano))
+	case KindError:
+		return jsonString(f.Err.Error())
+	case KindBytes:
+		return jsonString(string(f.Bytes))
+	default:
+		raw, err := json.Marshal(f.Any)
+		if err != nil {
+			return jsonString(fmt.Sprint(f.Any))
+		}
+		return string(raw)
+	}
+}
+
+// jsonFloat quotes NaN and infinities, which JSON numbers cannot express.
+func jsonFloat(v float64, bits int) string {
+	s := strconv.FormatFloat(v, 'g', -1, bits)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s) // strings always marshal
+	return string(b)
+}
+
+func formatLogfmt(e Entry) string {
+	var b strings.Builder
+	b.WriteString("ts=")
+	b.WriteString(e.At.UTC().Format(time.RFC3339Nano))
+	b.WriteString(" level=")
+	b.WriteString(e.Level.String())
+	b.WriteString(" msg=")
+	b.WriteString(logfmtValue(e.Message))
+	for i := range e.Fields {
+		f := &e.Fields[i]
+		if f.Kind == KindError && f.Err == nil {
+			continue
+		}
+		b.WriteByte(' ')
+		b.WriteString(f.K)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(logfmtString(f)))
+	}
+	return b.String()
+}
+
+func logfmtString(f *Field) string {
+	switch f.Kind {
+	case KindString:
+		return f.Str
+	case KindInt64:
+		return strconv.FormatInt(f.Int64, 10)
+	case KindUint64:
+		return strconv.FormatUint(f.Uint64, 10)
+	case KindFloat64:
+		return strconv.FormatFloat(f.Float64, 'g', -1, 64)
+	case KindFloat32:
+		return strconv.FormatFloat(f.Float64, 'g', -1, 32)
+	case KindBool:
+		return strconv.FormatBool(f.Bool)
+	case KindDuration:
+		return f.Dur.String()
+	case KindTime:
+		return f.Time.UTC().Format(time.RFC3339Nano)
+	case KindError:
+		return f.Err.Error()
+	case KindBytes:
+		return string(f.Bytes)
+	default:
+		if raw, err := json.Marshal(f.Any); err == nil {
+			return string(raw)
+		}
+		return fmt.Sprint(f.Any)
+	}
+}
+
+// logfmtValue quotes s when it is empty or contains spaces, quotes, '=' or
+// control characters.
+func logfmtValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || r == '\\' || r == 0x7f {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,53 @@
+package xlog
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testEntry() Entry {
+	return Entry{
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		At:      time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("X", 3600)),
+		Fields: []Field{
+			Str("path", "/var/lib/db data"),
+			Int64("free", 42),
+			Float32("ratio", 0.1),
+			Bool("alert", true),
+			Dur("since", 90*time.Second),
+			Err("error", errors.New(`no "space"`)),
+			{K: "nilerr", Kind: KindError},
+			Any("tags", []string{"a", "b"}),
+		},
+	}
+}
+
+func TestFormatEntry_JSON(t *testing.T) {
+	t.Parallel()
+
+	got := FormatEntry(testEntry(), FormatJSON)
+	want := `{"ts":"2024-01-02T02:04:05.000000006Z","level":"warn","msg":"disk almost full",` +
+		`"path":"/var/lib/db data","free":42,"ratio":0.1,"alert":true,"since":"1m30s",` +
+		`"error":"no \"space\"","tags":["a","b"]}`
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+	if !json.Valid([]byte(got)) {
+		t.Fatal("output is not valid JSON")
+	}
+}
+
+func TestFormatEntry_Logfmt(t *testing.T) {
+	t.Parallel()
+
+	got := FormatEntry(testEntry(), FormatLogfmt)
+	want := `ts=2024-01-02T02:04:05.000000006Z level=warn msg="disk almost full" ` +
+		`path="/var/lib/db data" free=42 ratio=0.1 alert=true since=1m30s ` +
+		`error="no \"space\"" tags="[\"a\",\"b\"]"`
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package xlog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// WithGoroutineID adds a "gid" field with the emitting goroutine's id to every
+// entry, to follow interleaved goroutines while debugging. Go has no API for
+// the id, so it is parsed from the "goroutine N [...]" header of
+// runtime.Stack: expect roughly a microsecond per entry. Keep it off in
+// production hot paths.
+func (b *Builder) WithGoroutineID() *Builder {
+	return b.WithAutoField("gid", func() Field { return Uint64("gid", goroutineID()) })
+}
+
+// goroutineID returns the current goroutine's id, or 0 if the stack header
+// cannot be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,39 @@
+package xlog
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBuilderWithGoroutineID(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithGoroutineID().Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info().Msg("worker")
+		}()
+	}
+	wg.Wait()
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	var gids []uint64
+	for _, e := range adapter.logs {
+		for _, f := range e.Fields {
+			if f.K == "gid" && f.Kind == KindUint64 && f.Uint64 != 0 {
+				gids = append(gids, f.Uint64)
+			}
+		}
+	}
+	if len(gids) != 2 || gids[0] == gids[1] {
+		t.Fatalf("gids = %v, want two distinct non-zero ids", gids)
+	}
+}
@@ -0,0 +1,42 @@
+package xlog
+
+// eventGroup records where an OpenGroup scope starts in Event.fields.
+type eventGroup struct {
+	name  string
+	start int
+}
+
+// OpenGroup starts a named group: fields added until the matching CloseGroup
+// are namespaced under name as "name.key". Groups nest ("outer.inner.key"),
+// and groups still open when Msg is called are closed automatically.
+// This suits conditional grouping:
+//
+//	ev := l.Info().OpenGroup("req")
+//	ev.Str("method", m)
+//	if user != "" {
+//		ev.OpenGroup("user").Str("id", user).CloseGroup()
+//	}
+//	ev.CloseGroup().Msg("handled")
+func (e *Event) OpenGroup(name string) *Event {
+	e.groups = append(e.groups, eventGroup{name: name, start: len(e.fields)})
+	return e
+}
+
+// CloseGroup ends the innermost open group. It is a no-op when no group is open.
+func (e *Event) CloseGroup() *Event {
+	n := len(e.groups)
+	if n == 0 {
+		return e
+	}
+	g := e.groups[n-1]
+	e.groups = e.groups[:n-1]
+	prefixKeys(g.name+".", e.fields[g.start:])
+	return e
+}
+
+// closeGroups closes every group still open; Msg and Emit call it.
+func (e *Event) closeGroups() {
+	for len(e.groups) > 0 {
+		e.CloseGroup()
+	}
+}
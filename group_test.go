@@ -0,0 +1,56 @@
+package xlog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventGroups(t *testing.T) {
+	t.Parallel()
+
+	keys := func(a *stubAdapter, i int) []string {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		var out []string
+		for _, f := range a.logs[i].Fields {
+			out = append(out, f.K)
+		}
+		return out
+	}
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	t.Run("balanced", func(t *testing.T) {
+		logger.Info().
+			Str("a", "1").
+			OpenGroup("req").
+			Str("method", "GET").
+			OpenGroup("user").Str("id", "u1").CloseGroup().
+			Int("status", 200).
+			CloseGroup().
+			Str("b", "2").
+			Msg("balanced")
+		want := []string{"a", "req.method", "req.user.id", "req.status", "b"}
+		if got := keys(ad, 0); !reflect.DeepEqual(got, want) {
+			t.Fatalf("keys = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unbalanced", func(t *testing.T) {
+		logger.Info().
+			CloseGroup(). // no open group: no-op
+			OpenGroup("outer").
+			Str("x", "1").
+			OpenGroup("inner").
+			Str("y", "2").
+			Msg("auto-closed")
+		want := []string{"outer.x", "outer.inner.y"}
+		if got := keys(ad, 1); !reflect.DeepEqual(got, want) {
+			t.Fatalf("keys = %v, want %v", got, want)
+		}
+	})
+}
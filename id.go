@@ -0,0 +1,70 @@
+package xlog
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// idGen holds the generator installed by SetIDGenerator; nil means randomID.
+var idGen atomic.Pointer[func() string]
+
+// SetIDGenerator replaces the generator behind GenerateID, used by
+// Builder.WithInstanceID and suitable for WithAutoField correlation ids, e.g.
+// to produce ULIDs or UUIDv7s. gen must be safe for concurrent use. A nil gen
+// restores the default random 16-hex-digit generator.
+func SetIDGenerator(gen func() string) {
+	if gen == nil {
+		idGen.Store(nil)
+		return
+	}
+	idGen.Store(&gen)
+}
+
+// GenerateID returns an id from the generator set via SetIDGenerator
+// (default: 16 random hex digits).
+func GenerateID() string {
+	if gen := idGen.Load(); gen != nil {
+		return (*gen)()
+	}
+	return randomID()
+}
+
+// randomID returns 64 random bits as 16 lowercase hex digits.
+func randomID() string {
+	v := rand.Uint64()
+	var b [16]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = hexDigits[v&0xf]
+		v >>= 4
+	}
+	return string(b[:])
+}
+
+// NewID returns a random RFC 4122 version 4 UUID string. It uses the
+// non-cryptographic, lock-free math/rand/v2 generator: fast enough for the
+// emit path, but not suitable for secrets.
+func NewID() string {
+	hi, lo := rand.Uint64(), rand.Uint64()
+	hi = hi&^(0xf<<12) | 0x4<<12 // version 4
+	lo = lo&^(0x3<<62) | 0x2<<62 // RFC 4122 variant
+
+	var b [36]byte
+	j := 0
+	for i := 0; i < 16; i++ {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			b[j] = '-'
+			j++
+		}
+		var v byte
+		if i < 8 {
+			v = byte(hi >> (56 - 8*i))
+		} else {
+			v = byte(lo >> (56 - 8*(i-8)))
+		}
+		b[j], b[j+1] = hexDigits[v>>4], hexDigits[v&0xf]
+		j += 2
+	}
+	return string(b[:])
+}
@@ -0,0 +1,68 @@
+package xlog
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewID_UUIDv4Shape(t *testing.T) {
+	t.Parallel()
+
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		if !uuidV4.MatchString(id) {
+			t.Fatalf("not a v4 UUID: %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate id %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestGenerateID_Default(t *testing.T) {
+	t.Parallel()
+
+	hex16 := regexp.MustCompile(`^[0-9a-f]{16}$`)
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		id := randomID()
+		if !hex16.MatchString(id) {
+			t.Fatalf("not 16 hex digits: %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate id %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+// Not parallel: replaces the process-wide generator.
+func TestSetIDGenerator(t *testing.T) {
+	defer SetIDGenerator(nil)
+
+	n := 0
+	SetIDGenerator(func() string {
+		n++
+		return "custom-" + strconv.Itoa(n)
+	})
+	if got := GenerateID(); got != "custom-1" {
+		t.Fatalf("GenerateID = %q, want custom-1", got)
+	}
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).WithInstanceID().Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	if got := logger.InstanceID(); got != "custom-2" {
+		t.Fatalf("InstanceID = %q, want custom-2", got)
+	}
+
+	SetIDGenerator(nil)
+	if got := GenerateID(); len(got) != 16 {
+		t.Fatalf("default generator not restored: %q", got)
+	}
+}
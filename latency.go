@@ -0,0 +1,39 @@
+package xlog
+
+import "time"
+
+// DefaultLatencyBounds are the bucket upper bounds LatencyBucket uses when
+// none are given.
+var DefaultLatencyBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyBucket adds d as a Dur field under k plus a "k_bucket" label naming
+// the first bound d does not exceed, e.g. "<=100ms", or ">10s" when d exceeds
+// every bound. bounds must be ascending; DefaultLatencyBounds is used when
+// none are given.
+func (e *Event) LatencyBucket(k string, d time.Duration, bounds ...time.Duration) *Event {
+	if len(bounds) == 0 {
+		bounds = DefaultLatencyBounds
+	}
+	return e.Dur(k, d).Str(k+"_bucket", latencyBucket(d, bounds))
+}
+
+func latencyBucket(d time.Duration, bounds []time.Duration) string {
+	for _, b := range bounds {
+		if d <= b {
+			return "<=" + b.String()
+		}
+	}
+	return ">" + bounds[len(bounds)-1].String()
+}
@@ -0,0 +1,51 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyBucketBounds(t *testing.T) {
+	t.Parallel()
+
+	bounds := []time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second}
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "<=10ms"},
+		{10 * time.Millisecond, "<=10ms"},
+		{10*time.Millisecond + 1, "<=100ms"},
+		{100 * time.Millisecond, "<=100ms"},
+		{time.Second, "<=1s"},
+		{time.Second + 1, ">1s"},
+	}
+	for _, c := range cases {
+		if got := latencyBucket(c.d, bounds); got != c.want {
+			t.Fatalf("latencyBucket(%s) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
+
+func TestEventLatencyBucket(t *testing.T) {
+	t.Parallel()
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().
+		LatencyBucket("latency", 250*time.Millisecond).
+		LatencyBucket("db", 3*time.Millisecond, time.Millisecond, 2*time.Millisecond).
+		Msg("done")
+
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	fs := ad.logs[0].Fields
+	assertHasDur(t, fs, "latency", 250*time.Millisecond)
+	assertHasStr(t, fs, "latency_bucket", "<=250ms")
+	assertHasDur(t, fs, "db", 3*time.Millisecond)
+	assertHasStr(t, fs, "db_bucket", ">2ms")
+}
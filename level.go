@@ -1,6 +1,9 @@
 package xlog
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Level is a numeric log severity. Lower numbers are more verbose.
 type Level int8
@@ -14,6 +17,15 @@ const (
 	LevelFatal Level = 12
 )
 
+// AllLevels lists the named levels from most to least verbose.
+var AllLevels = []Level{LevelTrace, LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal}
+
+// Enabled reports whether an entry at l passes a min level filter of min.
+func (l Level) Enabled(min Level) bool { return l >= min }
+
+// AtLeast reports whether l is as severe as or more severe than other.
+func (l Level) AtLeast(other Level) bool { return l >= other }
+
 func (l Level) String() string {
 	switch l {
 	case LevelTrace:
@@ -32,3 +44,64 @@ func (l Level) String() string {
 		return fmt.Sprintf("level(%d)", int(l))
 	}
 }
+
+// ParseLevel parses a level name as produced by Level.String, ignoring case
+// and surrounding whitespace. "warning" is accepted as an alias for warn.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	default:
+		return 0, fmt.Errorf("xlog: unknown level %q", s)
+	}
+}
+
+// ShortString returns a single-character level marker for compact text
+// output: T, D, I, W, E, F, or "?" for unnamed levels.
+func (l Level) ShortString() string {
+	switch l {
+	case LevelTrace:
+		return "T"
+	case LevelDebug:
+		return "D"
+	case LevelInfo:
+		return "I"
+	case LevelWarn:
+		return "W"
+	case LevelError:
+		return "E"
+	case LevelFatal:
+		return "F"
+	default:
+		return "?"
+	}
+}
+
+// CloudSeverity maps l to a Google Cloud Logging severity string.
+// Levels without a direct counterpart map to "DEFAULT".
+func CloudSeverity(l Level) string {
+	switch l {
+	case LevelTrace, LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARNING"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "CRITICAL"
+	default:
+		return "DEFAULT"
+	}
+}
@@ -0,0 +1,83 @@
+package xlog
+
+import "testing"
+
+func TestCloudSeverity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		l    Level
+		want string
+	}{
+		{LevelTrace, "DEBUG"},
+		{LevelDebug, "DEBUG"},
+		{LevelInfo, "INFO"},
+		{LevelWarn, "WARNING"},
+		{LevelError, "ERROR"},
+		{LevelFatal, "CRITICAL"},
+		{Level(2), "DEFAULT"},
+	}
+	for _, c := range cases {
+		if got := CloudSeverity(c.l); got != c.want {
+			t.Fatalf("CloudSeverity(%v) = %q, want %q", c.l, got, c.want)
+		}
+	}
+}
+
+func TestLevelOrderingHelpers(t *testing.T) {
+	t.Parallel()
+
+	if len(AllLevels) != 6 {
+		t.Fatalf("AllLevels has %d entries, want 6", len(AllLevels))
+	}
+	seen := map[string]bool{}
+	for i, l := range AllLevels {
+		seen[l.String()] = true
+		if i > 0 && !l.AtLeast(AllLevels[i-1]) {
+			t.Fatalf("AllLevels not ordered at %d: %v after %v", i, l, AllLevels[i-1])
+		}
+	}
+	for _, name := range []string{"trace", "debug", "info", "warn", "error", "fatal"} {
+		if !seen[name] {
+			t.Fatalf("AllLevels missing %s", name)
+		}
+	}
+
+	if !LevelWarn.Enabled(LevelInfo) || LevelDebug.Enabled(LevelInfo) || !LevelInfo.Enabled(LevelInfo) {
+		t.Fatal("Enabled mismatch")
+	}
+	if !LevelFatal.AtLeast(LevelError) || LevelTrace.AtLeast(LevelDebug) {
+		t.Fatal("AtLeast mismatch")
+	}
+}
+
+func TestLevelShortString(t *testing.T) {
+	t.Parallel()
+
+	want := []string{"T", "D", "I", "W", "E", "F"}
+	for i, l := range AllLevels {
+		if got := l.ShortString(); got != want[i] {
+			t.Fatalf("%v.ShortString() = %q, want %q", l, got, want[i])
+		}
+	}
+	if got := Level(3).ShortString(); got != "?" {
+		t.Fatalf("unnamed level ShortString() = %q, want ?", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	for _, l := range AllLevels {
+		got, err := ParseLevel(l.String())
+		if err != nil || got != l {
+			t.Fatalf("ParseLevel(%q) = %v, %v", l.String(), got, err)
+		}
+	}
+	if got, err := ParseLevel("  WARNING\n"); err != nil || got != LevelWarn {
+		t.Fatalf("ParseLevel(WARNING) = %v, %v", got, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Fatal("ParseLevel(verbose) should fail")
+	}
+}
@@ -1,7 +1,9 @@
 package xlog
 
 import (
+	"fmt"
 	"io"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -10,11 +12,29 @@ import (
 
 // Logger is a small facade that delegates to an Adapter, with a min level filter.
 // Patterns: Facade, Strategy (Adapter), Observer, Singleton (global)
+//
+// A nil *Logger is usable: its methods act on the global logger L() at call
+// time (Close is a no-op), so a library field left unconfigured logs through
+// whatever the application installed instead of panicking.
 type Logger struct {
 	ad     Adapter
 	min    *atomic.Int32 // stores Level in int32; pointer to avoid copying atomic values
 	clock  xclock.Clock
 	obs    []Observer // immutable slice set at construction
+	omin   Level      // lowest level any observer accepts (see AddObserverAt)
+	bound  []Field    // fields bound via Builder/With; immutable, copied on derive
+	smp    Sampler    // optional; shared with children
+	prefix string     // optional key prefix applied before dispatch
+	auto   []autoField
+	plevel Level  // Printf/Println level
+	iid    string // instance id; see Builder.WithInstanceID
+	stack  bool   // capture a "stack" field at or above smin
+	smin   Level
+	slow   time.Duration // TimeOp warn threshold
+	caller CallerFormat  // "caller" field format; zero disables
+	borrow bool          // ad implements adapterBorrowsFields
+	frozen bool          // see Frozen
+	ownLvl bool          // min level set via WithLevel; not propagated to ad
 	closed atomic.Bool
 }
 
@@ -25,9 +45,10 @@ func New(ad Adapter, min Level) *Logger {
 		ad = nopAdapter{}
 	}
 	l := &Logger{
-		ad:    ad,
-		min:   new(atomic.Int32),
-		clock: xclock.System(),
+		ad:     ad,
+		min:    new(atomic.Int32),
+		clock:  xclock.System(),
+		borrow: borrowsFields(ad),
 	}
 	l.min.Store(int32(min))
 	return l
@@ -36,30 +57,61 @@ func New(ad Adapter, min Level) *Logger {
 func newLogger(cfg Config) *Logger {
 	clk := cfg.Clock
 	if clk == nil {
-		clk = xclock.System()
+		clk = xclock.Default()
+	}
+	ad := cfg.Adapter
+	bound := copyFields(nil, cfg.Fields)
+	if cfg.InstanceID != "" {
+		bound = append(bound, Str("instance_id", cfg.InstanceID))
+	}
+	bound = prefixKeys(cfg.KeyPrefix, bound)
+	if len(bound) > 0 {
+		ad = ad.With(bound)
 	}
 	l := &Logger{
-		ad:    cfg.Adapter,
-		min:   new(atomic.Int32),
-		clock: clk,
+		ad:     ad,
+		min:    new(atomic.Int32),
+		clock:  clk,
+		bound:  bound,
+		smp:    cfg.Sampler,
+		prefix: cfg.KeyPrefix,
+		auto:   append([]autoField(nil), cfg.auto...),
+		plevel: cfg.PrintLevel,
+		iid:    cfg.InstanceID,
+		stack:  cfg.autoStack,
+		smin:   cfg.stackMin,
+		slow:   cfg.SlowThreshold,
+		caller: cfg.Caller,
+		borrow: borrowsFields(ad),
 	}
 	l.min.Store(int32(cfg.MinLevel))
 	if len(cfg.Observers) > 0 {
 		l.obs = append([]Observer(nil), cfg.Observers...)
+		l.omin = observerMin(l.obs)
 	}
 	return l
 }
 
-func (l *Logger) MinLevel() Level { return Level(l.min.Load()) }
+func (l *Logger) MinLevel() Level { return Level(l.orGlobal().min.Load()) }
+
+// InstanceID returns the id set via Builder.WithInstanceID (or
+// Config.InstanceID), shared by derived loggers; "" when none was set.
+func (l *Logger) InstanceID() string { return l.orGlobal().iid }
 
 func (l *Logger) SetMinLevel(min Level) {
+	l = l.orGlobal()
+	if l.frozen {
+		return
+	}
 	old := l.MinLevel()
 	if old == min {
 		return
 	}
 	l.min.Store(int32(min))
-	// Optional propagation to adapter (if constructed via New, not Builder)
-	if ls, ok := l.ad.(adapterLevelSetter); ok {
+	// Optional propagation to adapter (if constructed via New, not Builder).
+	// WithLevel children share ad with their parent, so they keep their level
+	// to themselves.
+	if ls, ok := l.ad.(adapterLevelSetter); ok && !l.ownLvl {
 		ls.SetMinLevel(min)
 	}
 	l.notifyConfig(old, min)
@@ -67,14 +119,79 @@ func (l *Logger) SetMinLevel(min Level) {
 
 // With returns a derived logger with bound fields.
 func (l *Logger) With(fs ...Field) *Logger {
+	l = l.orGlobal()
+	c := l.derive()
+	if l.prefix != "" {
+		fs = prefixKeys(l.prefix, copyFields(nil, fs))
+	}
+	c.ad = l.ad.With(fs)
+	c.borrow = borrowsFields(c.ad)
+	if len(fs) > 0 {
+		c.bound = append(append(make([]Field, 0, len(l.bound)+len(fs)), l.bound...), fs...)
+	}
+	return c
+}
+
+// BoundFields returns a copy of the fields bound to l via Builder and With,
+// in binding order.
+func (l *Logger) BoundFields() []Field { return copyFields(nil, l.orGlobal().bound) }
+
+// WithLevel returns a child logger with its own min level, sharing the adapter,
+// clock and observers. Changing either logger's level does not affect the
+// other, which allows e.g. verbose logging for a single request.
+//
+// The bundled zap, slog and zerolog adapters give the child its own backend
+// filter, so a level below the backend's shared one takes effect. With other
+// adapters, SetMinLevel on the child (or its descendants) never reaches the
+// backend, which keeps filtering on its own level.
+func (l *Logger) WithLevel(min Level) *Logger {
+	l = l.orGlobal()
+	c := l.derive()
+	c.min = new(atomic.Int32)
+	c.min.Store(int32(min))
+	if lf, ok := l.ad.(adapterLevelForker); ok {
+		c.ad = lf.WithMinLevel(min)
+		c.borrow = borrowsFields(c.ad)
+	} else {
+		c.ownLvl = true
+	}
+	return c
+}
+
+// derive returns a child sharing l's state. Never copy a Logger by value.
+func (l *Logger) derive() *Logger {
 	return &Logger{
-		ad:    l.ad.With(fs),
-		min:   l.min,   // share the same atomic.Int32 pointer; do NOT copy atomic by value
-		clock: l.clock, // share the same clock reference
-		obs:   l.obs,   // observers slice is immutable
+		ad:     l.ad,
+		min:    l.min,   // share the same atomic.Int32 pointer; do NOT copy atomic by value
+		clock:  l.clock, // share the same clock reference
+		obs:    l.obs,   // observers slice is immutable
+		omin:   l.omin,
+		bound:  l.bound, // immutable; With allocates a new slice
+		smp:    l.smp,
+		prefix: l.prefix,
+		auto:   l.auto,
+		plevel: l.plevel,
+		iid:    l.iid,
+		stack:  l.stack,
+		smin:   l.smin,
+		slow:   l.slow,
+		caller: l.caller,
+		borrow: l.borrow,
+		frozen: l.frozen,
+		ownLvl: l.ownLvl,
 	}
 }
 
+// Frozen returns a logger sharing l's adapter, level and observers that
+// cannot reconfigure them: SetMinLevel, SetWriter and Close are no-ops on it
+// and on loggers derived from it (With, WithLevel, ...). Hand it to libraries
+// that should log but not change process-wide logging state.
+func (l *Logger) Frozen() *Logger {
+	c := l.orGlobal().derive()
+	c.frozen = true
+	return c
+}
+
 // Event builder API (zerolog-style).
 
 func (l *Logger) Trace() *Event { return getEvent(l, LevelTrace) }
@@ -89,29 +206,155 @@ func (l *Logger) LogAt(level Level, msg string, fs ...Field) {
 	l.emit(level, msg, fs)
 }
 
-// emit is the single emission path for both builder and immediate APIs.
-func (l *Logger) emit(level Level, msg string, fs []Field) {
-	if l.closed.Load() {
+// Enabled reports whether an entry at level would currently be emitted,
+// i.e. the logger is not closed and level passes the min level filter.
+func (l *Logger) Enabled(level Level) bool {
+	l = l.orGlobal()
+	return !l.closed.Load() && level >= l.MinLevel()
+}
+
+func (l *Logger) TraceEnabled() bool { return l.Enabled(LevelTrace) }
+func (l *Logger) DebugEnabled() bool { return l.Enabled(LevelDebug) }
+func (l *Logger) InfoEnabled() bool  { return l.Enabled(LevelInfo) }
+func (l *Logger) WarnEnabled() bool  { return l.Enabled(LevelWarn) }
+func (l *Logger) ErrorEnabled() bool { return l.Enabled(LevelError) }
+func (l *Logger) FatalEnabled() bool { return l.Enabled(LevelFatal) }
+
+// Printf logs a fmt.Sprintf-formatted message without structured fields at the
+// print level (Builder.WithPrintLevel, default LevelInfo). It eases migration
+// from fmt/log-style call sites. Formatting is skipped when the level is off.
+func (l *Logger) Printf(format string, args ...any) {
+	l = l.orGlobal()
+	if !l.Enabled(l.plevel) {
 		return
 	}
-	if level < l.MinLevel() {
+	l.emit(l.plevel, fmt.Sprintf(format, args...), nil)
+}
+
+// Println is like Printf but formats its arguments as fmt.Sprintln does,
+// without the trailing newline.
+func (l *Logger) Println(args ...any) {
+	l = l.orGlobal()
+	if !l.Enabled(l.plevel) {
 		return
 	}
+	msg := fmt.Sprintln(args...)
+	l.emit(l.plevel, msg[:len(msg)-1], nil)
+}
+
+// emit is the single emission path for both builder and immediate APIs.
+// It reports whether the entry was dispatched, along with its timestamp and
+// the final event fields handed to the adapter.
+func (l *Logger) emit(level Level, msg string, fs []Field) (at time.Time, fields []Field, ok bool) {
+	l = l.orGlobal()
+	if !l.Enabled(level) {
+		return at, nil, false
+	}
 	// Snapshot time via platform abstraction.
-	at := l.clock.Now()
+	at = l.clock.Now()
+	if l.smp != nil && !l.smp.Sample(level, at, l.sampleFields(fs)) {
+		return at, nil, false
+	}
+
+	if l.borrow && len(l.obs) == 0 && l.prefix == "" && len(l.auto) == 0 && !l.stack && l.caller == 0 && !hasLazy(fs) {
+		// Nothing below would modify fs and nothing keeps it after Log
+		// returns, so the adapter may borrow it (see adapterBorrowsFields).
+		l.ad.Log(level, msg, at, fs)
+		return at, fs, true
+	}
 
 	// Defensive copy to avoid adapter misuse and caller aliasing.
-	var fields []Field
-	if len(fs) > 0 {
-		fields = append(make([]Field, 0, len(fs)), fs...)
+	fields = copyFields(nil, fs)
+	for _, af := range l.auto {
+		if !hasKey(fs, af.key) && !hasKey(l.bound, l.prefix+af.key) {
+			f := af.gen()
+			f.K = af.key
+			fields = append(fields, f)
+		}
 	}
+	if l.stack && level >= l.smin && !hasKey(fs, "stack") {
+		// Skip emit; captureStack drops the xlog frames above it (Msg, LogAt,
+		// TimeOp, Deprecated, ...).
+		fields = append(fields, Str("stack", captureStack(1)))
+	}
+	if l.caller != 0 && !hasKey(fs, "caller") {
+		fields = append(fields, Str("caller", captureCaller(l.caller)))
+	}
+	fields = prefixKeys(l.prefix, fields)
+	resolveLazy(fields)
 
 	l.ad.Log(level, msg, at, fields)
 	l.notifyEvent(level, msg, at, fields)
+	return at, fields, true
+}
+
+// sampleFields returns the fields a Sampler sees: the event fields followed by
+// the bound fields, so a key lookup finds the event's own value first. Keys
+// are reported without the logger's KeyPrefix.
+func (l *Logger) sampleFields(fs []Field) []Field {
+	if len(l.bound) == 0 {
+		return fs
+	}
+	out := make([]Field, 0, len(fs)+len(l.bound))
+	out = append(out, fs...)
+	for _, f := range l.bound {
+		f.K = strings.TrimPrefix(f.K, l.prefix)
+		out = append(out, f)
+	}
+	return out
+}
+
+func hasKey(fs []Field, k string) bool {
+	for i := range fs {
+		if fs[i].K == k {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixKeys prepends prefix to each key in place and returns fs.
+func prefixKeys(prefix string, fs []Field) []Field {
+	if prefix == "" {
+		return fs
+	}
+	for i := range fs {
+		fs[i].K = prefix + fs[i].K
+	}
+	return fs
+}
+
+// hasLazy reports whether fs holds a deferred Anyf value.
+func hasLazy(fs []Field) bool {
+	for i := range fs {
+		if fs[i].Kind == KindAny {
+			if _, ok := fs[i].Any.(lazyAny); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveLazy replaces deferred Anyf values in place. Only call it on fields
+// that are about to be dispatched.
+func resolveLazy(fs []Field) {
+	for i := range fs {
+		if fs[i].Kind != KindAny {
+			continue
+		}
+		if fn, ok := fs[i].Any.(lazyAny); ok {
+			fs[i].Any = fn()
+		}
+	}
 }
 
 // Close asks the adapter to release resources if supported.
+// On a nil or Frozen Logger it is a no-op.
 func (l *Logger) Close() {
+	if l == nil || l.frozen {
+		return
+	}
 	if !l.closed.CompareAndSwap(false, true) {
 		return
 	}
@@ -120,17 +363,37 @@ func (l *Logger) Close() {
 	}
 }
 
+// SetWriter redirects the adapter's output to w, e.g. to a capture buffer in a
+// test, and reports whether the adapter supports it (never on a Frozen
+// logger). Adapters that do (gelf, msgpack) switch atomically with respect to
+// entries being written and share the writer with loggers derived from the
+// same adapter.
+func (l *Logger) SetWriter(w io.Writer) bool {
+	l = l.orGlobal()
+	ws, ok := l.ad.(adapterWriterSetter)
+	ok = ok && !l.frozen
+	if ok {
+		ws.SetWriter(w)
+	}
+	return ok
+}
+
 // Observer notifications (best-effort, never panic).
 func (l *Logger) notifyEvent(level Level, msg string, at time.Time, fields []Field) {
-	if len(l.obs) == 0 {
+	if len(l.obs) == 0 || level < l.omin {
 		return
 	}
-	e := EventData{Level: level, Msg: msg, At: at}
-	if len(fields) > 0 {
-		e.Fields = append(make([]Field, 0, len(fields)), fields...)
+	// Observers see bound + event fields; adapters already hold bound fields
+	// and are passed event fields only, so nothing is encoded twice.
+	e := Entry{Level: level, Message: msg, At: at}
+	if n := len(l.bound) + len(fields); n > 0 {
+		e.Fields = append(append(make([]Field, 0, n), l.bound...), fields...)
 	}
 	for _, o := range l.obs {
-		func(o Observer, e EventData) {
+		if lo, ok := o.(levelObserver); ok && level < lo.min {
+			continue
+		}
+		func(o Observer, e Entry) {
 			defer func() { _ = recover() }()
 			o.OnEvent(e)
 		}(o, e)
@@ -169,6 +432,14 @@ func SetGlobal(l *Logger) *Logger {
 // L returns the global logger.
 func L() *Logger { return global.Load().(*Logger) }
 
+// orGlobal resolves a nil receiver to the global logger.
+func (l *Logger) orGlobal() *Logger {
+	if l == nil {
+		return L()
+	}
+	return l
+}
+
 // nopAdapter is a safe no-op adapter.
 type nopAdapter struct{}
 
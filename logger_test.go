@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/trickstertwo/xclock"
+	"github.com/trickstertwo/xclock/adapter/frozen"
 )
 
 // stubAdapter is a minimal Adapter for tests. It records logs and can write
@@ -32,11 +35,11 @@ func newStubAdapter(w *bytes.Buffer) *stubAdapter {
 }
 
 func (a *stubAdapter) With(fs []Field) Adapter {
-	child := *a
-	child.bound = append(copyFields(nil, a.bound), fs...)
 	// logs must not be shared with parent
-	child.logs = nil
-	return &child
+	return &stubAdapter{
+		bound:  append(copyFields(nil, a.bound), fs...),
+		writer: a.writer,
+	}
 }
 
 func (a *stubAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
@@ -93,13 +96,11 @@ func (a *stubAdapter) Log(level Level, msg string, at time.Time, fields []Field)
 }
 
 func TestGlobalAndFacade(t *testing.T) {
-	t.Parallel()
-
 	// Freeze time for determinism
 	old := xclock.Default()
 	defer xclock.SetDefault(old)
 	ft := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-	xclock.SetDefault(xclock.NewFrozen(ft))
+	xclock.SetDefault(frozen.New(ft))
 
 	adapter := newStubAdapter(nil)
 	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelDebug).Build()
@@ -151,13 +152,11 @@ func TestMinLevelFilter(t *testing.T) {
 }
 
 func TestWithAndObserverMerge(t *testing.T) {
-	t.Parallel()
-
 	// Freeze time
 	old := xclock.Default()
 	defer xclock.SetDefault(old)
 	ft := time.Date(2030, 2, 2, 3, 4, 5, 0, time.UTC)
-	xclock.SetDefault(xclock.NewFrozen(ft))
+	xclock.SetDefault(frozen.New(ft))
 
 	adapter := newStubAdapter(nil)
 	var got []Entry
@@ -220,3 +219,478 @@ func assertHasDur(t *testing.T, fs []Field, k string, v time.Duration) {
 	}
 	t.Fatalf("missing duration field %q=%s in %+v", k, v, fs)
 }
+
+func TestEnabled(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	if logger.DebugEnabled() {
+		t.Fatal("debug should be disabled at info min level")
+	}
+	if !logger.InfoEnabled() || !logger.ErrorEnabled() {
+		t.Fatal("info and error should be enabled at info min level")
+	}
+
+	ev := logger.Debug()
+	if ev.Enabled() {
+		t.Fatal("filtered event reported enabled")
+	}
+	ev.Msg("dropped")
+
+	ev = logger.Warn()
+	if !ev.Enabled() {
+		t.Fatal("warn event reported disabled")
+	}
+	ev.Msg("kept")
+
+	logger.Close()
+	if logger.ErrorEnabled() {
+		t.Fatal("closed logger reported enabled")
+	}
+	ev = logger.Error()
+	if ev.Enabled() {
+		t.Fatal("event on closed logger reported enabled")
+	}
+	ev.Msg("dropped")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if got := len(adapter.logs); got != 1 {
+		t.Fatalf("expected 1 log, got %d", got)
+	}
+}
+
+func TestBuilderWithComponent(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(&buf)).
+		WithComponent("billing", "1.4.2").
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().Msg("root")
+	logger.With(Str("request_id", "r-1")).Info().Msg("child")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, " component=billing version=1.4.2") {
+			t.Fatalf("missing component/version in %q", line)
+		}
+	}
+	if !strings.Contains(lines[1], " request_id=r-1") {
+		t.Fatalf("child lost its own bound field: %q", lines[1])
+	}
+}
+
+func TestWithLevel_IndependentMinLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	parent, err := NewBuilder().WithAdapter(newStubAdapter(&buf)).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	verbose := parent.WithLevel(LevelDebug)
+
+	parent.Debug().Msg("parent-debug")
+	verbose.Debug().Msg("child-debug")
+
+	verbose.SetMinLevel(LevelTrace)
+	if parent.MinLevel() != LevelInfo {
+		t.Fatalf("child SetMinLevel leaked to parent: %v", parent.MinLevel())
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "parent-debug") {
+		t.Fatalf("parent emitted debug: %q", out)
+	}
+	if !strings.Contains(out, "child-debug") {
+		t.Fatalf("child did not emit debug: %q", out)
+	}
+}
+
+// levelStubAdapter filters on its own min level, like zap's AtomicLevel.
+type levelStubAdapter struct {
+	*stubAdapter
+	min atomic.Int32
+}
+
+func (a *levelStubAdapter) SetMinLevel(min Level) { a.min.Store(int32(min)) }
+
+func (a *levelStubAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
+	if level < Level(a.min.Load()) {
+		return
+	}
+	a.stubAdapter.Log(level, msg, at, fields)
+}
+
+func TestWithLevel_SetMinLevelDoesNotTouchSharedAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter := &levelStubAdapter{stubAdapter: newStubAdapter(nil)}
+	parent, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := parent.WithLevel(LevelInfo)
+	child.SetMinLevel(LevelError)
+	child.With(Str("k", "v")).SetMinLevel(LevelFatal)
+
+	parent.Info().Msg("parent-info")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 1 || adapter.logs[0].Msg != "parent-info" {
+		t.Fatalf("parent output changed by child SetMinLevel: %+v", adapter.logs)
+	}
+}
+
+func TestBuilderWithKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct{ prefix, want string }{
+		{"app_", " app_component=db app_version=1 app_req=r-1 app_n=1"},
+		{"", " component=db version=1 req=r-1 n=1"},
+	} {
+		var buf bytes.Buffer
+		logger, err := NewBuilder().
+			WithAdapter(newStubAdapter(&buf)).
+			WithComponent("db", "1").
+			WithKeyPrefix(tc.prefix).
+			Build()
+		if err != nil {
+			t.Fatalf("build logger: %v", err)
+		}
+		logger.With(Str("req", "r-1")).Info().Int("n", 1).Msg("m")
+
+		if !strings.HasSuffix(strings.TrimSpace(buf.String()), strings.TrimSpace(tc.want)) {
+			t.Fatalf("prefix %q: got %q, want suffix %q", tc.prefix, buf.String(), tc.want)
+		}
+	}
+}
+
+func TestBuilderWithAutoField(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithAutoField("request_id", func() Field { return Str("request_id", NewID()) }).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().Msg("generated")
+	logger.Info().Str("request_id", "own").Msg("event wins")
+	bound := logger.With(Str("request_id", "bound"))
+	bound.Info().Msg("bound wins")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 2 {
+		t.Fatalf("expected 2 root logs, got %d", len(adapter.logs))
+	}
+	gen := adapter.logs[0].Fields
+	if len(gen) != 1 || gen[0].K != "request_id" || !uuidV4.MatchString(gen[0].Str) {
+		t.Fatalf("expected generated request_id, got %+v", gen)
+	}
+	own := adapter.logs[1].Fields
+	if len(own) != 1 || own[0].Str != "own" {
+		t.Fatalf("event request_id should be kept as-is, got %+v", own)
+	}
+
+	child := bound.ad.(*stubAdapter)
+	child.mu.Lock()
+	defer child.mu.Unlock()
+	if n := len(child.logs); n != 1 || len(child.logs[0].Fields) != 1 || child.logs[0].Fields[0].Str != "bound" {
+		t.Fatalf("bound request_id should suppress generation, got %+v", child.logs)
+	}
+}
+
+func TestBuilderWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithDefaults(Str("env", "prod"), Int64("shard", 1)).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().Msg("defaults")
+	logger.Info().Str("env", "staging").Msg("override")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	def := adapter.logs[0].Fields
+	if len(def) != 2 {
+		t.Fatalf("expected both defaults, got %+v", def)
+	}
+	assertHasStr(t, def, "env", "prod")
+	assertHasInt64(t, def, "shard", 1)
+
+	over := adapter.logs[1].Fields
+	if len(over) != 2 || over[0].K != "env" || over[0].Str != "staging" || over[1].K != "shard" {
+		t.Fatalf("event env should replace the default, got %+v", over)
+	}
+}
+
+func TestPrintfShim(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithPrintLevel(LevelWarn).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Printf("retry %d of %s", 2, "upload")
+	logger.Println("ready", 3)
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(adapter.logs))
+	}
+	if e := adapter.logs[0]; e.Level != LevelWarn || e.Msg != "retry 2 of upload" || len(e.Fields) != 0 {
+		t.Fatalf("unexpected Printf entry: %+v", e)
+	}
+	if e := adapter.logs[1]; e.Level != LevelWarn || e.Msg != "ready 3" {
+		t.Fatalf("unexpected Println entry: %+v", e)
+	}
+}
+
+func TestBuilderWithInstanceID(t *testing.T) {
+	t.Parallel()
+
+	build := func() (*Logger, *stubAdapter) {
+		logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).WithInstanceID().Build()
+		if err != nil {
+			t.Fatalf("build logger: %v", err)
+		}
+		return logger, logger.ad.(*stubAdapter)
+	}
+	a, adA := build()
+	b, _ := build()
+
+	if a.InstanceID() == "" {
+		t.Fatal("InstanceID is empty")
+	}
+	if a.InstanceID() == b.InstanceID() {
+		t.Fatalf("two loggers share instance id %q", a.InstanceID())
+	}
+	if got := a.With(Str("k", "v")).InstanceID(); got != a.InstanceID() {
+		t.Fatalf("child InstanceID = %q, want %q", got, a.InstanceID())
+	}
+
+	a.Info().Msg("one")
+	a.Info().Msg("two")
+	adA.mu.Lock()
+	defer adA.mu.Unlock()
+	for _, e := range adA.logs {
+		assertHasStr(t, e.Fields, "instance_id", a.InstanceID())
+	}
+	if len(adA.logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(adA.logs))
+	}
+}
+
+func TestBuilderWithAutoStack(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithAutoStack(LevelError).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Msg("fine")
+	logger.Error().Msg("broken")
+	logger.LogAt(LevelFatal, "worse")
+	slow, err := NewBuilder().
+		WithAdapter(adapter).
+		WithMinLevel(LevelDebug).
+		WithAutoStack(LevelDebug).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	slow.TimeOp("load")() // logged from inside xlog's stop closure
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs[0].Fields) != 0 {
+		t.Fatalf("info entry got fields: %+v", adapter.logs[0].Fields)
+	}
+	if len(adapter.logs) != 4 {
+		t.Fatalf("expected 4 logs, got %d", len(adapter.logs))
+	}
+	for _, e := range adapter.logs[1:] {
+		stack := e.Fields[len(e.Fields)-1]
+		if stack.K != "stack" {
+			t.Fatalf("%s entry missing stack: %+v", e.Msg, e.Fields)
+		}
+		first, _, _ := strings.Cut(stack.Str, "\n")
+		if !strings.HasSuffix(first, ".TestBuilderWithAutoStack") {
+			t.Fatalf("stack should start at the caller, got %q", first)
+		}
+	}
+}
+
+func TestBuilderAddObserverAt(t *testing.T) {
+	t.Parallel()
+
+	var all, errs []Level
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithMinLevel(LevelDebug).
+		AddObserverAt(ObserverFunc(func(e Entry) { errs = append(errs, e.Level) }), LevelError).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	if logger.omin != LevelError {
+		t.Fatalf("observer min = %v, want error", logger.omin)
+	}
+	both, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithMinLevel(LevelDebug).
+		AddObserverAt(ObserverFunc(func(Entry) {}), LevelError).
+		AddObserver(ObserverFunc(func(e Entry) { all = append(all, e.Level) })).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	for _, l := range []*Logger{logger, both} {
+		l.Debug().Msg("d")
+		l.Info().Msg("i")
+		l.Error().Msg("e")
+	}
+	if len(errs) != 1 || errs[0] != LevelError {
+		t.Fatalf("gated observer saw %v, want [error]", errs)
+	}
+	if len(all) != 3 {
+		t.Fatalf("ungated observer saw %v, want 3 entries", all)
+	}
+}
+
+// rawAdapter keeps the exact slices it is given (which only an adapter that
+// does not implement adapterBorrowsFields may do).
+type rawAdapter struct {
+	mu  sync.Mutex
+	got [][]Field
+}
+
+func (a *rawAdapter) With([]Field) Adapter { return a }
+
+func (a *rawAdapter) Log(_ Level, _ string, _ time.Time, fields []Field) {
+	a.mu.Lock()
+	a.got = append(a.got, fields)
+	a.mu.Unlock()
+}
+
+// borrowingAdapter is rawAdapter declaring BorrowsFields, so tests can observe
+// whether the logger skipped its copy.
+type borrowingAdapter struct{ rawAdapter }
+
+func (a *borrowingAdapter) With([]Field) Adapter { return a }
+
+func (*borrowingAdapter) BorrowsFields() bool { return true }
+
+func TestEmit_BorrowingAdapterSkipsCopy(t *testing.T) {
+	t.Parallel()
+
+	ad := &borrowingAdapter{}
+	logger, err := NewBuilder().WithAdapter(ad).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	fs := []Field{Str("k", "v")}
+	logger.LogAt(LevelInfo, "borrowed", fs...)
+
+	if got := ad.got[0]; len(got) != 1 || &got[0] != &fs[0] {
+		t.Fatalf("borrowing adapter got a copy, want the caller's slice")
+	}
+}
+
+func TestEmit_CopiesWhenObserversOrAdapterRetain(t *testing.T) {
+	t.Parallel()
+
+	const n = 50
+	var mu sync.Mutex
+	var seen []Entry
+	inner := ObserverFunc(func(e Entry) {
+		mu.Lock()
+		seen = append(seen, e)
+		mu.Unlock()
+	})
+	obs, closer := NewAsyncObserver(inner, n, nil)
+	borrowing := &borrowingAdapter{}
+	withObs, err := NewBuilder().WithAdapter(borrowing).AddObserver(obs).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	retaining := &rawAdapter{}
+	plain, err := NewBuilder().WithAdapter(retaining).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	// Pooled events reuse their field slices, so any entry that aliased one
+	// would be overwritten by a later iteration.
+	for i := 0; i < n; i++ {
+		withObs.Info().Int("i", i).Msg("async")
+		plain.Info().Int("i", i).Msg("retained")
+	}
+	if err := closer.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if len(seen) != n {
+		t.Fatalf("observer saw %d entries, want %d", len(seen), n)
+	}
+	for i := 0; i < n; i++ {
+		assertHasInt64(t, seen[i].Fields, "i", int64(i))
+		assertHasInt64(t, borrowing.got[i], "i", int64(i))
+		assertHasInt64(t, retaining.got[i], "i", int64(i))
+	}
+}
+
+func TestLoggerFrozen(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	frozen := logger.Frozen()
+	frozen.SetMinLevel(LevelError)
+	frozen.With(Str("k", "v")).SetMinLevel(LevelDebug)
+	frozen.Close()
+
+	if got := logger.MinLevel(); got != LevelInfo {
+		t.Fatalf("min level changed through frozen logger: %v", got)
+	}
+	frozen.Info().Msg("still logs")
+	logger.SetMinLevel(LevelWarn) // the owner keeps control
+	frozen.Info().Msg("filtered")
+
+	if len(adapter.logs) != 1 || frozen.MinLevel() != LevelWarn {
+		t.Fatalf("logs = %d, frozen min = %v; want 1 and warn", len(adapter.logs), frozen.MinLevel())
+	}
+}
@@ -0,0 +1,20 @@
+package xlog
+
+// StrMap adds m as a JSON object with sorted keys. The map is carried as a
+// KindAny value; the bundled adapters render it as a native object (slog
+// group, zap object, zerolog dict) without reflection. A nil map logs as {}.
+// m must not be mutated until the event is emitted.
+func (e *Event) StrMap(k string, m map[string]string) *Event {
+	if m == nil {
+		m = map[string]string{}
+	}
+	return e.Any(k, m)
+}
+
+// IntMap is like StrMap for int64 values.
+func (e *Event) IntMap(k string, m map[string]int64) *Event {
+	if m == nil {
+		m = map[string]int64{}
+	}
+	return e.Any(k, m)
+}
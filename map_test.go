@@ -0,0 +1,44 @@
+package xlog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEventStrMapIntMap(t *testing.T) {
+	t.Parallel()
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().
+		StrMap("labels", map[string]string{"zone": "b", "app": "api"}).
+		IntMap("counts", map[string]int64{"ok": 2, "err": 1}).
+		StrMap("none", nil).
+		IntMap("zero", nil).
+		Msg("maps")
+
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	want := map[string]string{
+		"labels": `{"app":"api","zone":"b"}`,
+		"counts": `{"err":1,"ok":2}`,
+		"none":   `{}`,
+		"zero":   `{}`,
+	}
+	for _, f := range ad.logs[0].Fields {
+		if f.Kind != KindAny {
+			t.Fatalf("%s: kind = %v, want KindAny", f.K, f.Kind)
+		}
+		b, err := json.Marshal(f.Any)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", f.K, err)
+		}
+		if string(b) != want[f.K] {
+			t.Fatalf("%s = %s, want %s", f.K, b, want[f.K])
+		}
+	}
+}
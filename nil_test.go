@@ -0,0 +1,43 @@
+package xlog
+
+import "testing"
+
+// Not parallel: mutates the global logger.
+func TestNilLogger_RoutesToGlobal(t *testing.T) {
+	orig := L()
+	defer SetGlobal(orig)
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	SetGlobal(logger)
+
+	var nl *Logger
+	nl.Info().Str("k", "v").Msg("event")
+	nl.LogAt(LevelWarn, "immediate")
+	nl.Printf("printf %d", 1)
+	nl.Println("println")
+	nl.TimeOp("op")()
+	if !nl.DebugEnabled() || nl.TraceEnabled() {
+		t.Fatal("Enabled should reflect the global logger's min level")
+	}
+	if nl.MinLevel() != LevelDebug || nl.InstanceID() != "" || len(nl.BoundFields()) != 0 {
+		t.Fatal("accessors should reflect the global logger")
+	}
+	child := nl.With(Str("c", "1"))
+	child.Info().Msg("child")
+	nl.WithLevel(LevelError).Info().Msg("filtered")
+	nl.Close()
+	if L().closed.Load() {
+		t.Fatal("Close on a nil logger closed the global logger")
+	}
+
+	if got := len(adapter.logs); got != 5 {
+		t.Fatalf("global logger got %d entries, want 5: %+v", got, adapter.logs)
+	}
+	if c := child.ad.(*stubAdapter); len(c.logs) != 1 {
+		t.Fatalf("child logger got %d entries, want 1", len(c.logs))
+	}
+}
@@ -0,0 +1,40 @@
+package xlog
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// Nullable adds a database nullable value (sql.NullString, sql.NullInt64,
+// sql.Null[T], ...) as its underlying value, or as nil when it is not Valid,
+// instead of the {"String":...,"Valid":...} struct shape Any would produce.
+// It accepts any driver.Valuer; a nil pointer is logged as nil and a Value
+// error as a KindError field.
+func (e *Event) Nullable(k string, v driver.Valuer) *Event {
+	if v == nil || isNilValue(v) {
+		return e.Any(k, nil)
+	}
+	dv, err := v.Value()
+	if err != nil {
+		e.fields = append(e.fields, Field{K: k, Kind: KindError, Err: err})
+		return e
+	}
+	switch x := dv.(type) {
+	case nil:
+		return e.Any(k, nil)
+	case string:
+		return e.Str(k, x)
+	case int64:
+		return e.Int64(k, x)
+	case float64:
+		return e.Float64(k, x)
+	case bool:
+		return e.Bool(k, x)
+	case time.Time:
+		return e.Time(k, x)
+	case []byte:
+		return e.Bytes(k, x)
+	default:
+		return e.Any(k, x)
+	}
+}
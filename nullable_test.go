@@ -0,0 +1,41 @@
+package xlog
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestEventNullable(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	logger.Info().
+		Nullable("name", sql.NullString{String: "ada", Valid: true}).
+		Nullable("nick", sql.NullString{String: "ignored", Valid: false}).
+		Nullable("age", sql.NullInt64{Int64: 36, Valid: true}).
+		Nullable("score", sql.NullInt64{}).
+		Nullable("ptr", (*sql.NullString)(nil)).
+		Msg("row")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	fs := adapter.logs[0].Fields
+	assertHasStr(t, fs, "name", "ada")
+	assertHasInt64(t, fs, "age", 36)
+	for _, k := range []string{"nick", "score", "ptr"} {
+		var f *Field
+		for i := range fs {
+			if fs[i].K == k {
+				f = &fs[i]
+			}
+		}
+		if f == nil || f.Kind != KindAny || f.Any != nil {
+			t.Fatalf("expected null %q, got %+v", k, f)
+		}
+	}
+}
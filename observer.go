@@ -1,19 +1,26 @@
 package xlog
 
 import (
+	"math"
 	"time"
 )
 
 // Observer pattern
 
-// EventData is a read-only snapshot of an emitted log event.
-type EventData struct {
-	Level  Level
-	Msg    string
-	At     time.Time
-	Fields []Field // copy per emit; safe to hold
+// Entry is a read-only snapshot of an emitted log entry.
+type Entry struct {
+	Level   Level
+	Message string
+	At      time.Time
+	Fields  []Field // copy per emit; safe to hold
 }
 
+// EventData is the former name of Entry. Its Msg field is now Entry.Message;
+// code reading e.Msg must be updated, as an alias cannot rename fields.
+//
+// Deprecated: use Entry.
+type EventData = Entry
+
 // ConfigChange captures logger configuration updates of interest to observers.
 type ConfigChange struct {
 	OldMin Level
@@ -23,6 +30,34 @@ type ConfigChange struct {
 // Observer receives notifications for events and config changes.
 // Implementations MUST be concurrency-safe.
 type Observer interface {
-	OnEvent(e EventData)
+	OnEvent(e Entry)
 	OnConfig(c ConfigChange)
 }
+
+// levelObserver gates an Observer to entries at or above min (AddObserverAt).
+type levelObserver struct {
+	Observer
+	min Level
+}
+
+// observerMin returns the lowest level any of obs accepts.
+func observerMin(obs []Observer) Level {
+	min := Level(math.MaxInt8)
+	for _, o := range obs {
+		lo, ok := o.(levelObserver)
+		if !ok {
+			return math.MinInt8
+		}
+		if lo.min < min {
+			min = lo.min
+		}
+	}
+	return min
+}
+
+// ObserverFunc adapts a plain function to the Observer interface.
+// Config changes are ignored.
+type ObserverFunc func(e Entry)
+
+func (f ObserverFunc) OnEvent(e Entry)       { f(e) }
+func (ObserverFunc) OnConfig(_ ConfigChange) {}
@@ -0,0 +1,16 @@
+package xlog
+
+import "fmt"
+
+// Panic records a recovered panic value: v formatted with %v as a "panic"
+// string field, whatever its type, plus a "stack" field captured at the call
+// (inside a deferred func this still includes the panicking frames).
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			l.Error().Panic(r).Msg("recovered")
+//		}
+//	}()
+func (e *Event) Panic(v any) *Event {
+	return e.Str("panic", fmt.Sprintf("%v", v)).Str("stack", captureStack(1))
+}
@@ -0,0 +1,50 @@
+package xlog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEventPanic(t *testing.T) {
+	t.Parallel()
+
+	type failure struct {
+		Code int
+		Op   string
+	}
+	cases := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"string", "boom", "boom"},
+		{"error", errors.New("bad state"), "bad state"},
+		{"struct", failure{Code: 7, Op: "sync"}, "{7 sync}"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter := newStubAdapter(nil)
+			logger, err := NewBuilder().WithAdapter(adapter).Build()
+			if err != nil {
+				t.Fatalf("build logger: %v", err)
+			}
+			func() {
+				defer func() {
+					logger.Error().Panic(recover()).Msg("recovered")
+				}()
+				panic(c.v)
+			}()
+
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+			fs := adapter.logs[0].Fields
+			assertHasStr(t, fs, "panic", c.want)
+			if len(fs) != 2 || fs[1].K != "stack" || !strings.Contains(fs[1].Str, "TestEventPanic") {
+				t.Fatalf("missing stack: %+v", fs)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package rotate provides io.Writers that bound the disk space used by logs.
+package rotate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trickstertwo/xclock"
+)
+
+// flushInterval is how often Write persists the ring on its own.
+const flushInterval = time.Second
+
+// CappedFile keeps the most recent maxBytes written to it in memory and
+// persists them to a single file by writing a temporary file next to it and
+// renaming it over the target, so readers (e.g. a crash reporter) always see a
+// complete snapshot of the last ~maxBytes of logs. The file is rewritten at
+// most once per second from Write, and on Sync and Close, and is fsynced before
+// the rename. When older data has been dropped mid-line, the snapshot starts at
+// the first complete line.
+//
+// It is safe for concurrent use; pass it as the Writer of any adapter.
+type CappedFile struct {
+	mu    sync.Mutex
+	path  string
+	ring  []byte // len == maxBytes
+	next  int    // ring index of the next byte to write
+	full  bool   // the ring holds maxBytes of data
+	edge  byte   // stream byte just before the oldest kept one; '\n' at a line start
+	dirty bool   // written since last flush
+	last  time.Time
+	clock xclock.Clock
+}
+
+// NewCappedFile returns a CappedFile persisting to path. maxBytes < 1 is
+// treated as 1. Nothing is written to disk until the first flush.
+func NewCappedFile(path string, maxBytes int64) *CappedFile {
+	if maxBytes < 1 {
+		maxBytes = 1
+	}
+	clk := xclock.Default()
+	return &CappedFile{path: path, ring: make([]byte, maxBytes), edge: '\n', last: clk.Now(), clock: clk}
+}
+
+// WithClock sets the clock that paces periodic flushes (e.g. a test clock).
+// Call it before the first Write.
+func (c *CappedFile) WithClock(clk xclock.Clock) *CappedFile {
+	if clk != nil {
+		c.mu.Lock()
+		c.clock, c.last = clk, clk.Now()
+		c.mu.Unlock()
+	}
+	return c
+}
+
+// Write appends p to the ring, dropping the oldest bytes beyond maxBytes. It
+// returns an error only if a periodic flush fails; p is kept in memory either
+// way.
+func (c *CappedFile) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(p)
+	c.trackEdgeLocked(p)
+	if n >= len(c.ring) {
+		copy(c.ring, p[n-len(c.ring):])
+		c.next, c.full = 0, true
+	} else {
+		k := copy(c.ring[c.next:], p)
+		if k < n {
+			copy(c.ring, p[k:])
+			c.full = true
+		}
+		c.next = (c.next + n) % len(c.ring)
+		if c.next == 0 && n > 0 {
+			c.full = true
+		}
+	}
+	c.dirty = c.dirty || n > 0
+	if c.clock.Since(c.last) >= flushInterval {
+		return n, c.flushLocked()
+	}
+	return n, nil
+}
+
+// trackEdgeLocked records in c.edge the byte that will precede the oldest one
+// kept once p is written, if writing p drops any data.
+func (c *CappedFile) trackEdgeLocked(p []byte) {
+	size, n := len(c.ring), len(p)
+	if n > size {
+		c.edge = p[n-size-1]
+		return
+	}
+	used, oldest := c.next, 0
+	if c.full {
+		used, oldest = size, c.next
+	}
+	if dropped := used + n - size; dropped > 0 {
+		c.edge = c.ring[(oldest+dropped-1)%size]
+	}
+}
+
+// Sync persists the current contents to the file.
+func (c *CappedFile) Sync() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flushLocked()
+}
+
+// Close persists the current contents. The CappedFile stays usable.
+func (c *CappedFile) Close() error { return c.Sync() }
+
+func (c *CappedFile) flushLocked() error {
+	c.last = c.clock.Now()
+	if !c.dirty {
+		return nil
+	}
+	data := c.ring[:c.next]
+	if c.full {
+		data = append(append(make([]byte, 0, len(c.ring)), c.ring[c.next:]...), c.ring[:c.next]...)
+		// The oldest line was cut by the wrap; start at the next complete one.
+		if c.edge != '\n' {
+			if i := bytes.IndexByte(data, '\n'); i >= 0 {
+				data = data[i+1:]
+			}
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,143 @@
+package rotate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xclock"
+	"github.com/trickstertwo/xclock/adapter/frozen"
+)
+
+// stepClock is a frozen clock whose time tests move forward explicitly.
+type stepClock struct {
+	xclock.Clock
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *stepClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *stepClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func TestCappedFile_KeepsMostRecentLines(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "last.log")
+	const max = 1000
+	f := NewCappedFile(path, max)
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(f, "line %03d %s\n", i, strings.Repeat("x", 30))
+		if i%50 == 49 {
+			if err := f.Sync(); err != nil {
+				t.Fatalf("sync: %v", err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if len(got) > max {
+				t.Fatalf("file is %d bytes, cap is %d", len(got), max)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(got), "\n"), "\n")
+			if want := fmt.Sprintf("line %03d ", i); !strings.HasPrefix(lines[len(lines)-1], want) {
+				t.Fatalf("last line = %q, want prefix %q", lines[len(lines)-1], want)
+			}
+			if !strings.HasPrefix(lines[0], "line ") {
+				t.Fatalf("first line is partial: %q", lines[0])
+			}
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestCappedFile_ShortContentAndOversizedWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "last.log")
+	f := NewCappedFile(path, 16)
+	f.Write([]byte("a\n"))
+	if err := f.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "a\n" {
+		t.Fatalf("file = %q, want %q", got, "a\n")
+	}
+
+	f.Write(bytes.Repeat([]byte("0123456789\n"), 3))
+	if err := f.Sync(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "0123456789\n" {
+		t.Fatalf("file = %q, want the last complete line", got)
+	}
+}
+
+func TestCappedFile_WrapOnLineBoundaryKeepsFirstLine(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "last.log")
+	f := NewCappedFile(path, 8)
+	f.Write([]byte("aaa\nbbb\n")) // fills the ring exactly
+	if err := f.Sync(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "aaa\nbbb\n" {
+		t.Fatalf("file = %q, want both lines", got)
+	}
+
+	f.Write([]byte("ccc\n")) // drops exactly "aaa\n"
+	if err := f.Sync(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "bbb\nccc\n" {
+		t.Fatalf("file = %q, want %q", got, "bbb\nccc\n")
+	}
+
+	f.Write([]byte("dd\n")) // cuts "bbb\n" mid-line
+	if err := f.Sync(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "ccc\ndd\n" {
+		t.Fatalf("file = %q, want %q", got, "ccc\ndd\n")
+	}
+}
+
+func TestCappedFile_PeriodicFlushUsesClock(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "last.log")
+	clk := &stepClock{Clock: frozen.New(time.Unix(0, 0)), t: time.Unix(0, 0)}
+	f := NewCappedFile(path, 64).WithClock(clk)
+
+	f.Write([]byte("first\n"))
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("flushed before the interval elapsed: %v", err)
+	}
+
+	clk.Advance(flushInterval)
+	if _, err := f.Write([]byte("second\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "first\nsecond\n" {
+		t.Fatalf("file = %q, want both lines after the interval", got)
+	}
+}
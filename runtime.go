@@ -0,0 +1,32 @@
+package xlog
+
+import (
+	"os"
+	"runtime"
+	"sync"
+)
+
+var (
+	runtimeOnce   sync.Once
+	runtimeFields []Field
+)
+
+// RuntimeFields returns process metadata suitable for binding via With:
+// host, pid, go_version, gomaxprocs and num_cpu. Values are captured on the
+// first call and cached; each call returns a fresh copy.
+func RuntimeFields() []Field {
+	runtimeOnce.Do(func() {
+		host, _ := os.Hostname()
+		runtimeFields = []Field{
+			Str("host", host),
+			Int64("pid", int64(os.Getpid())),
+			Str("go_version", runtime.Version()),
+			Int64("gomaxprocs", int64(runtime.GOMAXPROCS(0))),
+			Int64("num_cpu", int64(runtime.NumCPU())),
+		}
+	})
+	return copyFields(nil, runtimeFields)
+}
+
+// HostPIDFields returns just the host and pid fields from RuntimeFields.
+func HostPIDFields() []Field { return RuntimeFields()[:2] }
@@ -0,0 +1,43 @@
+package xlog
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRuntimeFields(t *testing.T) {
+	t.Parallel()
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	fs := RuntimeFields()
+	assertHasStr(t, fs, "host", host)
+	assertHasInt64(t, fs, "pid", int64(os.Getpid()))
+	assertHasStr(t, fs, "go_version", runtime.Version())
+	assertHasInt64(t, fs, "num_cpu", int64(runtime.NumCPU()))
+
+	fs[0].Str = "mutated"
+	assertHasStr(t, RuntimeFields(), "host", host)
+}
+
+func TestBuilderWithRuntimeInfo(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(&buf)).WithRuntimeInfo().Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Info().Msg("boot")
+
+	line := buf.String()
+	if !strings.Contains(line, " pid="+strconv.Itoa(os.Getpid())) || !strings.Contains(line, " go_version=") {
+		t.Fatalf("runtime fields missing: %q", line)
+	}
+}
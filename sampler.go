@@ -0,0 +1,118 @@
+package xlog
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Sampler decides whether an entry that passed the min level filter is emitted.
+// It sees the entry's timestamp (from the logger's clock), the event fields and
+// then the logger's bound fields (Builder.WithFields, With), without KeyPrefix.
+// Implementations MUST be concurrency-safe and MUST NOT retain fields.
+type Sampler interface {
+	Sample(level Level, at time.Time, fields []Field) bool
+}
+
+// perKeyIdleTTL is how long an untouched bucket is kept before eviction.
+const perKeyIdleTTL = time.Minute
+
+// PerKeySampler rate-limits entries independently per key (e.g. tenant id),
+// using one token bucket per key that refills at perSecond tokens per second
+// with a burst of perSecond. Buckets idle for longer than a minute are evicted.
+type PerKeySampler struct {
+	keyFn     func([]Field) string
+	perSecond float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewPerKeySampler returns a Sampler limiting each key returned by keyFn to
+// perSecond entries per second. perSecond <= 0 disables limiting.
+func NewPerKeySampler(keyFn func([]Field) string, perSecond int) *PerKeySampler {
+	return &PerKeySampler{
+		keyFn:     keyFn,
+		perSecond: float64(perSecond),
+		buckets:   make(map[string]*tokenBucket),
+	}
+}
+
+// Sample implements Sampler.
+func (s *PerKeySampler) Sample(_ Level, at time.Time, fields []Field) bool {
+	if s.perSecond <= 0 {
+		return true
+	}
+	key := s.keyFn(fields)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweepLocked(at)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: s.perSecond, last: at}
+		s.buckets[key] = b
+	} else if elapsed := at.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * s.perSecond
+		if b.tokens > s.perSecond {
+			b.tokens = s.perSecond
+		}
+		b.last = at
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweepLocked evicts idle buckets at most once per perKeyIdleTTL.
+func (s *PerKeySampler) sweepLocked(at time.Time) {
+	if at.Sub(s.lastSweep) < perKeyIdleTTL {
+		return
+	}
+	s.lastSweep = at
+	for k, b := range s.buckets {
+		if at.Sub(b.last) >= perKeyIdleTTL {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+// FieldKey returns a key function for NewPerKeySampler that extracts the
+// string value of the first field named k (empty when absent).
+func FieldKey(k string) func([]Field) string {
+	return func(fs []Field) string {
+		for i := range fs {
+			if fs[i].K == k && fs[i].Kind == KindString {
+				return fs[i].Str
+			}
+		}
+		return ""
+	}
+}
+
+// RateSampler passes a random fraction of entries below LevelError; errors
+// and above always pass.
+type RateSampler struct {
+	rate float64
+}
+
+// NewRateSampler returns a Sampler keeping roughly rate (0..1) of the entries
+// below LevelError. A rate outside (0, 1) disables sampling.
+func NewRateSampler(rate float64) *RateSampler { return &RateSampler{rate: rate} }
+
+// Sample implements Sampler.
+func (s *RateSampler) Sample(level Level, _ time.Time, _ []Field) bool {
+	if level >= LevelError || s.rate <= 0 || s.rate >= 1 {
+		return true
+	}
+	return rand.Float64() < s.rate
+}
@@ -0,0 +1,138 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPerKeySampler_LimitsKeysIndependently(t *testing.T) {
+	t.Parallel()
+
+	s := NewPerKeySampler(FieldKey("tenant"), 2)
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := []Field{Str("tenant", "a")}
+	b := []Field{Str("tenant", "b")}
+
+	passed := map[string]int{}
+	for i := 0; i < 5; i++ {
+		if s.Sample(LevelInfo, at, a) {
+			passed["a"]++
+		}
+	}
+	if s.Sample(LevelInfo, at, b) {
+		passed["b"]++
+	}
+	if passed["a"] != 2 || passed["b"] != 1 {
+		t.Fatalf("unexpected pass counts: %v", passed)
+	}
+
+	// One second later tenant a has refilled.
+	if !s.Sample(LevelInfo, at.Add(time.Second), a) {
+		t.Fatal("tenant a not refilled after 1s")
+	}
+}
+
+func TestPerKeySampler_EvictsIdleKeys(t *testing.T) {
+	t.Parallel()
+
+	s := NewPerKeySampler(FieldKey("tenant"), 10)
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Sample(LevelInfo, at, []Field{Str("tenant", "a")})
+	s.Sample(LevelInfo, at, []Field{Str("tenant", "b")})
+
+	s.Sample(LevelInfo, at.Add(2*perKeyIdleTTL), []Field{Str("tenant", "c")})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.buckets) != 1 {
+		t.Fatalf("expected idle buckets evicted, have %d", len(s.buckets))
+	}
+	if _, ok := s.buckets["c"]; !ok {
+		t.Fatal("active bucket missing")
+	}
+}
+
+func TestBuilderWithSampler(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithSampler(NewPerKeySampler(FieldKey("tenant"), 1)).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(Str("svc", "api"))
+	for i := 0; i < 3; i++ {
+		child.Info().Str("tenant", "noisy").Msg("flood")
+	}
+	logger.Info().Str("tenant", "quiet").Msg("ok")
+
+	total := 0
+	for _, a := range []*stubAdapter{adapter, child.ad.(*stubAdapter)} {
+		a.mu.Lock()
+		total += len(a.logs)
+		a.mu.Unlock()
+	}
+	if total != 2 {
+		t.Fatalf("expected 1 noisy + 1 quiet entry, got %d", total)
+	}
+}
+
+func TestPerKeySampler_SeesBoundFields(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithKeyPrefix("app_").
+		WithSampler(NewPerKeySampler(FieldKey("tenant"), 1)).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	noisy := logger.With(Str("tenant", "noisy"))
+	quiet := logger.With(Str("tenant", "quiet"))
+	for i := 0; i < 3; i++ {
+		noisy.Info().Msg("flood")
+	}
+	quiet.Info().Msg("ok")
+	noisy.Info().Str("tenant", "override").Msg("event value wins")
+
+	total := 0
+	for _, l := range []*Logger{noisy, quiet} {
+		a := l.ad.(*stubAdapter)
+		a.mu.Lock()
+		total += len(a.logs)
+		a.mu.Unlock()
+	}
+	if total != 3 {
+		t.Fatalf("expected 1 noisy + 1 quiet + 1 override entry, got %d", total)
+	}
+}
+
+func TestRateSampler_FractionAndErrorBypass(t *testing.T) {
+	t.Parallel()
+
+	s := NewRateSampler(0.25)
+	const n = 10000
+	passed := 0
+	for i := 0; i < n; i++ {
+		if s.Sample(LevelInfo, time.Time{}, nil) {
+			passed++
+		}
+		if !s.Sample(LevelError, time.Time{}, nil) || !s.Sample(LevelFatal, time.Time{}, nil) {
+			t.Fatal("errors must bypass sampling")
+		}
+	}
+	if passed < n*20/100 || passed > n*30/100 {
+		t.Fatalf("passed %d of %d info entries, want about 25%%", passed, n)
+	}
+
+	for _, rate := range []float64{0, -1, 1, 2} {
+		if !NewRateSampler(rate).Sample(LevelDebug, time.Time{}, nil) {
+			t.Fatalf("rate %v should disable sampling", rate)
+		}
+	}
+}
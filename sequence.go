@@ -0,0 +1,46 @@
+package xlog
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// SequenceAdapter decorates next so every entry carries a monotonically
+// increasing "seq" uint64 field, starting at 1, for ordering and gap detection
+// downstream. Adapters derived via With share the same counter, so sequence
+// numbers are unique across a logger and all of its children.
+func SequenceAdapter(next Adapter) Adapter {
+	return &seqAdapter{next: next, seq: new(atomic.Uint64)}
+}
+
+type seqAdapter struct {
+	next Adapter
+	seq  *atomic.Uint64
+}
+
+func (a *seqAdapter) With(fs []Field) Adapter {
+	return &seqAdapter{next: a.next.With(fs), seq: a.seq}
+}
+
+func (a *seqAdapter) Log(level Level, msg string, at time.Time, fields []Field) {
+	fs := make([]Field, 0, len(fields)+1)
+	fs = append(fs, fields...)
+	fs = append(fs, Uint64("seq", a.seq.Add(1)))
+	a.next.Log(level, msg, at, fs)
+}
+
+// SetMinLevel forwards min-level configuration to next when supported.
+func (a *seqAdapter) SetMinLevel(min Level) {
+	if ls, ok := a.next.(adapterLevelSetter); ok {
+		ls.SetMinLevel(min)
+	}
+}
+
+// Close closes next when it implements io.Closer.
+func (a *seqAdapter) Close() error {
+	if c, ok := a.next.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
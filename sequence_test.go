@@ -0,0 +1,43 @@
+package xlog
+
+import "testing"
+
+func TestSequenceAdapter(t *testing.T) {
+	t.Parallel()
+
+	stub := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(SequenceAdapter(stub)).WithMinLevel(LevelDebug).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(Str("component", "db"))
+
+	logger.Info().Msg("a")
+	child.Info().Msg("b")
+	logger.Info().Msg("c")
+	child.Warn().Msg("d")
+
+	seqOf := func(a *stubAdapter) []uint64 {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		var out []uint64
+		for _, e := range a.logs {
+			for _, f := range e.Fields {
+				if f.K == "seq" && f.Kind == KindUint64 {
+					out = append(out, f.Uint64)
+				}
+			}
+		}
+		return out
+	}
+
+	parent := seqOf(stub)
+	if len(parent) != 2 || parent[0] != 1 || parent[1] != 3 {
+		t.Fatalf("parent seq = %v, want [1 3]", parent)
+	}
+	childStub := child.ad.(*seqAdapter).next.(*stubAdapter)
+	got := seqOf(childStub)
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Fatalf("child seq = %v, want [2 4]", got)
+	}
+}
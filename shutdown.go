@@ -0,0 +1,16 @@
+package xlog
+
+// OnShutdown captures the start time from l's clock and returns a function to
+// defer in main. When called it logs "shutting down" at info with the uptime
+// since OnShutdown was called, then closes l (see Logger.Close). A nil l uses
+// the global logger.
+//
+//	defer xlog.OnShutdown(logger)()
+func OnShutdown(l *Logger) func() {
+	l = l.orGlobal()
+	start := l.clock.Now()
+	return func() {
+		l.LogAt(LevelInfo, "shutting down", Dur("uptime", l.clock.Since(start)))
+		l.Close()
+	}
+}
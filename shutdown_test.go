@@ -0,0 +1,40 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+)
+
+// closingAdapter records whether Close was called.
+type closingAdapter struct {
+	*stubAdapter
+	closed bool
+}
+
+func (a *closingAdapter) Close() error {
+	a.closed = true
+	return nil
+}
+
+func TestOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	clk := newStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ad := &closingAdapter{stubAdapter: newStubAdapter(nil)}
+	logger, err := NewBuilder().WithAdapter(ad).WithClock(clk).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	done := OnShutdown(logger)
+	clk.Advance(90 * time.Second)
+	done()
+
+	if len(ad.logs) != 1 || ad.logs[0].Msg != "shutting down" {
+		t.Fatalf("logs = %+v, want one shutdown entry", ad.logs)
+	}
+	assertHasDur(t, ad.logs[0].Fields, "uptime", 90*time.Second)
+	if !ad.closed {
+		t.Fatal("adapter was not closed")
+	}
+}
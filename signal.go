@@ -0,0 +1,85 @@
+package xlog
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// sigLevel remembers the min level that was active before the first signal
+// toggle so RestoreSignalLevel can put it back.
+var sigLevel struct {
+	mu    sync.Mutex
+	prev  Level
+	saved bool
+}
+
+// InstallSignalLevelToggle sets the global logger's min level to level each
+// time sig is received (e.g. SIGUSR1 -> LevelError during an incident) and logs
+// the change. The level in effect before the first toggle is remembered for
+// RestoreSignalLevel. Call stop to unregister the handler.
+func InstallSignalLevelToggle(sig os.Signal, level Level) (stop func()) {
+	return onSignal(sig, func() { toggleSignalLevel(sig, level) })
+}
+
+// RestoreSignalLevel restores the min level saved by InstallSignalLevelToggle
+// each time sig is received (e.g. SIGUSR2). Call stop to unregister the handler.
+func RestoreSignalLevel(sig os.Signal) (stop func()) {
+	return onSignal(sig, func() { restoreSignalLevel(sig) })
+}
+
+func onSignal(sig os.Signal, fn func()) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				fn()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+func toggleSignalLevel(sig os.Signal, level Level) {
+	l := L()
+	sigLevel.mu.Lock()
+	old := l.MinLevel()
+	if !sigLevel.saved {
+		sigLevel.prev = old
+		sigLevel.saved = true
+	}
+	l.SetMinLevel(level)
+	sigLevel.mu.Unlock()
+
+	// Log at the new min level so the notice itself is never filtered.
+	l.LogAt(level, "min level changed by signal",
+		Str("signal", sig.String()), Str("from", old.String()), Str("to", level.String()))
+}
+
+func restoreSignalLevel(sig os.Signal) {
+	l := L()
+	sigLevel.mu.Lock()
+	if !sigLevel.saved {
+		sigLevel.mu.Unlock()
+		return
+	}
+	prev := sigLevel.prev
+	sigLevel.saved = false
+	old := l.MinLevel()
+	l.SetMinLevel(prev)
+	sigLevel.mu.Unlock()
+
+	l.LogAt(prev, "min level restored by signal",
+		Str("signal", sig.String()), Str("from", old.String()), Str("to", prev.String()))
+}
@@ -0,0 +1,47 @@
+package xlog
+
+import (
+	"os"
+	"testing"
+)
+
+// Not parallel: mutates the global logger.
+func TestSignalLevelToggle(t *testing.T) {
+	orig := L()
+	defer SetGlobal(orig)
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	SetGlobal(logger)
+
+	stop := InstallSignalLevelToggle(os.Interrupt, LevelError)
+	defer stop()
+	stopRestore := RestoreSignalLevel(os.Interrupt)
+	defer stopRestore()
+
+	toggleSignalLevel(os.Interrupt, LevelError)
+	if got := L().MinLevel(); got != LevelError {
+		t.Fatalf("min level after toggle = %v, want error", got)
+	}
+	toggleSignalLevel(os.Interrupt, LevelError) // repeated toggles keep the original level
+	Info().Msg("suppressed")
+
+	restoreSignalLevel(os.Interrupt)
+	if got := L().MinLevel(); got != LevelInfo {
+		t.Fatalf("min level after restore = %v, want info", got)
+	}
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 3 {
+		t.Fatalf("expected 2 toggle notices and 1 restore notice, got %d", len(adapter.logs))
+	}
+	if e := adapter.logs[0]; e.Level != LevelError || e.Msg != "min level changed by signal" {
+		t.Fatalf("unexpected toggle notice: %+v", e)
+	}
+	assertHasStr(t, adapter.logs[0].Fields, "to", "error")
+	assertHasStr(t, adapter.logs[2].Fields, "to", "info")
+}
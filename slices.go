@@ -0,0 +1,18 @@
+package xlog
+
+import "time"
+
+// Typed slice helpers. The slices are carried as KindAny values; the zap and
+// zerolog adapters render them with their native array encoders, so each
+// element follows the backend's configured duration/time encoding. Other
+// adapters encode them like any other value. v must not be mutated until the
+// event is emitted.
+
+// Bools adds v as an array of booleans.
+func (e *Event) Bools(k string, v []bool) *Event { return e.Any(k, v) }
+
+// Durs adds v as an array of durations.
+func (e *Event) Durs(k string, v []time.Duration) *Event { return e.Any(k, v) }
+
+// Times adds v as an array of timestamps.
+func (e *Event) Times(k string, v []time.Time) *Event { return e.Any(k, v) }
@@ -0,0 +1,30 @@
+package xlog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventTypedSlices(t *testing.T) {
+	t.Parallel()
+
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(adapter).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	durs := []time.Duration{time.Second, 2 * time.Millisecond}
+	times := []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	bools := []bool{true, false}
+	logger.Info().Durs("durs", durs).Times("times", times).Bools("flags", bools).Msg("slices")
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	want := map[string]any{"durs": durs, "times": times, "flags": bools}
+	for _, f := range adapter.logs[0].Fields {
+		if f.Kind != KindAny || !reflect.DeepEqual(f.Any, want[f.K]) {
+			t.Fatalf("%s = %#v, want %#v", f.K, f.Any, want[f.K])
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package xlog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogTee is an Observer re-emitting every Entry on slog.Default.
+type slogTee struct{}
+
+// WithTeeToSlog also sends every entry to slog.Default (looked up per entry, so
+// a later slog.SetDefault is honored), for teams migrating from slog that want
+// existing handlers to keep receiving output. xlog levels map 1:1 onto slog
+// levels. It is an observer, so the configured adapter still gets everything.
+// Do not combine it with a slog.Default that itself writes through this logger.
+func (b *Builder) WithTeeToSlog() *Builder { return b.AddObserver(slogTee{}) }
+
+func (slogTee) OnEvent(e Entry) {
+	h := slog.Default().Handler()
+	if !h.Enabled(context.Background(), slog.Level(e.Level)) {
+		return
+	}
+	r := slog.NewRecord(e.At, slog.Level(e.Level), e.Message, 0)
+	for i := range e.Fields {
+		r.AddAttrs(slogAttr(&e.Fields[i]))
+	}
+	_ = h.Handle(context.Background(), r)
+}
+
+func (slogTee) OnConfig(ConfigChange) {}
+
+func slogAttr(f *Field) slog.Attr {
+	switch f.Kind {
+	case KindString:
+		return slog.String(f.K, f.Str)
+	case KindInt64:
+		return slog.Int64(f.K, f.Int64)
+	case KindUint64:
+		return slog.Uint64(f.K, f.Uint64)
+	case KindFloat64, KindFloat32:
+		return slog.Float64(f.K, f.Float64)
+	case KindBool:
+		return slog.Bool(f.K, f.Bool)
+	case KindDuration:
+		return slog.Duration(f.K, f.Dur)
+	case KindTime:
+		return slog.Time(f.K, f.Time)
+	case KindError:
+		if f.Err == nil {
+			return slog.Any(f.K, nil)
+		}
+		return slog.String(f.K, f.Err.Error())
+	case KindBytes:
+		return slog.String(f.K, string(f.Bytes))
+	default:
+		return slog.Any(f.K, f.Any)
+	}
+}
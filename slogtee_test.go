@@ -0,0 +1,34 @@
+package xlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+// Not parallel: swaps slog.Default.
+func TestWithTeeToSlog_WritesToSlogDefault(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	var buf bytes.Buffer
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithTeeToSlog().Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	logger.Warn().Str("user", "ada").Int("n", 3).Msg("migrated")
+
+	if len(ad.logs) != 1 {
+		t.Fatalf("adapter got %d entries, want 1", len(ad.logs))
+	}
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("slog output %q: %v", buf.String(), err)
+	}
+	if rec["level"] != "WARN" || rec["msg"] != "migrated" || rec["user"] != "ada" || rec["n"] != float64(3) {
+		t.Fatalf("slog record = %v", rec)
+	}
+}
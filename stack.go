@@ -0,0 +1,38 @@
+package xlog
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// stackDepth bounds the number of frames captured for the "stack" field.
+const stackDepth = 32
+
+// captureStack formats the goroutine's stack like debug.Stack, one
+// "function\n\tfile:line" pair per frame, skipping skip frames above its caller
+// and then any xlog frames, so the stack starts at the first caller outside
+// xlog (see isInternalFrame).
+func captureStack(skip int) string {
+	var pcs [stackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	f, more := frames.Next()
+	for more && isInternalFrame(f.File) {
+		f, more = frames.Next()
+	}
+	var b strings.Builder
+	for {
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+		if !more {
+			break
+		}
+		b.WriteByte('\n')
+		f, more = frames.Next()
+	}
+	return b.String()
+}
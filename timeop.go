@@ -0,0 +1,20 @@
+package xlog
+
+// TimeOp starts timing an operation on the logger's clock and returns a stop
+// function that logs "operation finished" with op=name and its elapsed time.
+// The entry is at debug, or at warn when elapsed exceeds the threshold set via
+// Builder.WithSlowThreshold.
+//
+//	defer l.TimeOp("load config")()
+func (l *Logger) TimeOp(name string) (stop func()) {
+	l = l.orGlobal()
+	start := l.clock.Now()
+	return func() {
+		d := l.clock.Since(start)
+		level := LevelDebug
+		if l.slow > 0 && d > l.slow {
+			level = LevelWarn
+		}
+		l.LogAt(level, "operation finished", Str("op", name), Dur("elapsed", d))
+	}
+}
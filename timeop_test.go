@@ -0,0 +1,72 @@
+package xlog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xclock"
+	"github.com/trickstertwo/xclock/adapter/frozen"
+)
+
+// stepClock is a frozen clock whose time tests move forward explicitly.
+type stepClock struct {
+	xclock.Clock
+	mu sync.Mutex
+	t  time.Time
+}
+
+func newStepClock(t time.Time) *stepClock { return &stepClock{Clock: frozen.New(t), t: t} }
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *stepClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *stepClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func TestLoggerTimeOp(t *testing.T) {
+	t.Parallel()
+
+	clk := newStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	adapter := newStubAdapter(nil)
+	logger, err := NewBuilder().
+		WithAdapter(adapter).
+		WithClock(clk).
+		WithMinLevel(LevelDebug).
+		WithSlowThreshold(100 * time.Millisecond).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	stop := logger.TimeOp("fast")
+	clk.Advance(40 * time.Millisecond)
+	stop()
+
+	stop = logger.TimeOp("slow")
+	clk.Advance(250 * time.Millisecond)
+	stop()
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(adapter.logs))
+	}
+	if e := adapter.logs[0]; e.Level != LevelDebug {
+		t.Fatalf("fast op logged at %v, want debug", e.Level)
+	}
+	assertHasStr(t, adapter.logs[0].Fields, "op", "fast")
+	assertHasDur(t, adapter.logs[0].Fields, "elapsed", 40*time.Millisecond)
+	if e := adapter.logs[1]; e.Level != LevelWarn {
+		t.Fatalf("slow op logged at %v, want warn", e.Level)
+	}
+	assertHasDur(t, adapter.logs[1].Fields, "elapsed", 250*time.Millisecond)
+}
@@ -0,0 +1,31 @@
+package xlog
+
+import "time"
+
+// Timer measures elapsed time on the process monotonic clock, independent of
+// the logger's (possibly frozen or offset) clock and of wall-clock jumps.
+type Timer struct {
+	l     *Logger
+	start time.Time
+}
+
+// StartTimer starts a Timer bound to l. Unlike TimeOp, which follows the
+// logger's clock so tests can control it, the result reflects real elapsed
+// time:
+//
+//	t := l.StartTimer()
+//	rows := load()
+//	t.Log(xlog.LevelInfo, "loaded", xlog.Int64("rows", int64(rows)))
+func (l *Logger) StartTimer() Timer {
+	return Timer{l: l.orGlobal(), start: time.Now()} // time.Now carries a monotonic reading
+}
+
+// Stop returns the time elapsed since StartTimer. It may be called repeatedly.
+func (t Timer) Stop() time.Duration { return time.Since(t.start) }
+
+// Log logs msg through the logger that started t, with its bound fields and
+// level, adding the elapsed time as "elapsed" after fields.
+func (t Timer) Log(level Level, msg string, fields ...Field) {
+	d := t.Stop()
+	t.l.orGlobal().LogAt(level, msg, append(fields[:len(fields):len(fields)], Dur("elapsed", d))...)
+}
@@ -0,0 +1,55 @@
+package xlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xclock/adapter/frozen"
+)
+
+func TestStartTimer_MonotonicUnderFrozenClock(t *testing.T) {
+	t.Parallel()
+
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithClock(frozen.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	tm := logger.StartTimer()
+	time.Sleep(time.Millisecond)
+	if d := tm.Stop(); d < time.Millisecond {
+		t.Fatalf("elapsed = %v, want >= 1ms despite the frozen clock", d)
+	}
+}
+
+func TestTimer_LogUsesStartingLogger(t *testing.T) {
+	t.Parallel()
+
+	logger, err := NewBuilder().
+		WithAdapter(newStubAdapter(nil)).
+		WithMinLevel(LevelInfo).
+		Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(Str("req", "r-1"))
+
+	tm := child.StartTimer()
+	time.Sleep(time.Millisecond)
+	tm.Log(LevelDebug, "below min level")
+	tm.Log(LevelInfo, "loaded", Int64("rows", 3))
+
+	logs := child.ad.(*stubAdapter).logs
+	if len(logs) != 1 || logs[0].Msg != "loaded" {
+		t.Fatalf("logs = %+v, want only the info entry", logs)
+	}
+	fs := logs[0].Fields
+	assertHasStr(t, fs, "req", "r-1")
+	assertHasInt64(t, fs, "rows", 3)
+	if last := fs[len(fs)-1]; last.K != "elapsed" || last.Kind != KindDuration || last.Dur < time.Millisecond {
+		t.Fatalf("last field = %+v, want elapsed >= 1ms", last)
+	}
+}
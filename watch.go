@@ -0,0 +1,72 @@
+package xlog
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// levelFilePollInterval is how often WatchLevelFile re-reads the file.
+const levelFilePollInterval = time.Second
+
+// WatchLevelFile sets l's min level from the file at path (a single level name,
+// see ParseLevel) and keeps it in sync by polling the file, so operators can
+// change verbosity by editing it without a restart. Content that does not
+// parse is ignored with a warning; the current level is kept. It returns an
+// error only if the file cannot be read initially. Call stop to end watching.
+// A nil l watches for the global logger. Polling uses a wall-clock ticker, not
+// l's clock, so loggers built with a frozen clock still pick up edits.
+func WatchLevelFile(path string, l *Logger) (stop func(), err error) {
+	l = l.orGlobal()
+	w := &levelFileWatcher{path: path, l: l}
+	if err := w.check(); err != nil {
+		return nil, err
+	}
+
+	t := time.NewTicker(levelFilePollInterval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-t.C:
+				_ = w.check()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.Stop()
+			close(done)
+		})
+	}, nil
+}
+
+type levelFileWatcher struct {
+	path string
+	l    *Logger
+	last string // content seen on the previous check
+}
+
+// check re-reads the file and applies its level if the content changed.
+func (w *levelFileWatcher) check() error {
+	b, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	content := string(b)
+	if content == w.last {
+		return nil
+	}
+	w.last = content
+
+	level, err := ParseLevel(content)
+	if err != nil {
+		w.l.Warn().Str("path", w.path).Str("content", content).Err(err).Msg("ignoring invalid level file")
+		return nil
+	}
+	w.l.SetMinLevel(level)
+	return nil
+}
@@ -0,0 +1,91 @@
+package xlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWatchLevelFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "level")
+	write := func(s string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(s), 0o600); err != nil {
+			t.Fatalf("write level file: %v", err)
+		}
+	}
+
+	ad := newStubAdapter(nil)
+	logger, err := NewBuilder().WithAdapter(ad).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+
+	if _, err := WatchLevelFile(path, logger); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+
+	write("debug\n")
+	stop, err := WatchLevelFile(path, logger)
+	if err != nil {
+		t.Fatalf("WatchLevelFile: %v", err)
+	}
+	stop()
+	if got := logger.MinLevel(); got != LevelDebug {
+		t.Fatalf("initial level = %v, want debug", got)
+	}
+
+	// Drive the watcher by hand instead of waiting for the poll ticker.
+	w := &levelFileWatcher{path: path, l: logger}
+	write("error")
+	if err := w.check(); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if got := logger.MinLevel(); got != LevelError {
+		t.Fatalf("level after edit = %v, want error", got)
+	}
+
+	write("warn")
+	_ = w.check()
+	write("loud")
+	if err := w.check(); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if got := logger.MinLevel(); got != LevelWarn {
+		t.Fatalf("invalid content changed level to %v", got)
+	}
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	if n := len(ad.logs); n != 1 || ad.logs[0].Level != LevelWarn {
+		t.Fatalf("expected one warning for invalid content, got %+v", ad.logs)
+	}
+	assertHasStr(t, ad.logs[0].Fields, "content", "loud")
+}
+
+// Not parallel: mutates the global logger.
+func TestWatchLevelFile_NilLoggerUsesGlobal(t *testing.T) {
+	orig := L()
+	defer SetGlobal(orig)
+
+	logger, err := NewBuilder().WithAdapter(newStubAdapter(nil)).WithMinLevel(LevelInfo).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	SetGlobal(logger)
+
+	path := filepath.Join(t.TempDir(), "level")
+	if err := os.WriteFile(path, []byte("debug"), 0o600); err != nil {
+		t.Fatalf("write level file: %v", err)
+	}
+	var nl *Logger
+	stop, err := WatchLevelFile(path, nl)
+	if err != nil {
+		t.Fatalf("WatchLevelFile: %v", err)
+	}
+	stop()
+	if got := logger.MinLevel(); got != LevelDebug {
+		t.Fatalf("global level = %v, want debug", got)
+	}
+}
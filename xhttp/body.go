@@ -0,0 +1,31 @@
+// Package xhttp provides helpers for logging HTTP traffic through xlog.
+package xhttp
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// LogBody attaches body to ev under key as a string. Bodies longer than
+// maxLen bytes (maxLen > 0) are cut at a rune boundary at or below maxLen and
+// flagged with key+"_truncated"=true and key+"_len" (the original length).
+// Binary bodies (invalid UTF-8) are not attached; key is set to "len:N".
+func LogBody(ev *xlog.Event, key string, body []byte, maxLen int) {
+	if !utf8.Valid(body) {
+		ev.Str(key, "len:"+strconv.Itoa(len(body)))
+		return
+	}
+	if maxLen <= 0 || len(body) <= maxLen {
+		ev.Str(key, string(body))
+		return
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	ev.Str(key, string(body[:cut])).
+		Bool(key+"_truncated", true).
+		Int(key+"_len", len(body))
+}
@@ -0,0 +1,52 @@
+package xhttp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trickstertwo/xlog"
+)
+
+func TestLogBody(t *testing.T) {
+	t.Parallel()
+
+	logger := xlog.New(nil, xlog.LevelInfo)
+	fields := func(body []byte, maxLen int) map[string]xlog.Field {
+		ev := logger.Info()
+		LogBody(ev, "body", body, maxLen)
+		out := map[string]xlog.Field{}
+		for _, f := range ev.Snapshot() {
+			out[f.K] = f
+		}
+		ev.Msg("req")
+		return out
+	}
+
+	t.Run("short json", func(t *testing.T) {
+		got := fields([]byte(`{"id":1}`), 64)
+		if len(got) != 1 || got["body"].Str != `{"id":1}` {
+			t.Fatalf("unexpected fields: %+v", got)
+		}
+	})
+
+	t.Run("long truncated", func(t *testing.T) {
+		body := []byte(`{"name":"` + strings.Repeat("é", 10) + `"}`)
+		got := fields(body, 12) // byte 12 falls inside an "é"
+		if s := got["body"].Str; s != `{"name":"é` {
+			t.Fatalf("body = %q, want cut at a rune boundary", s)
+		}
+		if !got["body_truncated"].Bool {
+			t.Fatal("missing body_truncated marker")
+		}
+		if n := got["body_len"].Int64; n != int64(len(body)) {
+			t.Fatalf("body_len = %d, want %d", n, len(body))
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		got := fields([]byte{0x89, 'P', 'N', 'G', 0xff, 0x00}, 64)
+		if len(got) != 1 || got["body"].Str != "len:6" {
+			t.Fatalf("unexpected fields: %+v", got)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package xhttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// Redacted replaces the value of headers listed in HeaderFields' redact list.
+const Redacted = "***"
+
+// HeaderFields returns one string field per header in allow that is present
+// in h, in allow order. Keys are the lowercased header names and multiple
+// values are joined with ", ". Headers also listed in redact (e.g.
+// Authorization, Cookie) are emitted as Redacted. Names match
+// case-insensitively; absent headers are skipped.
+func HeaderFields(h http.Header, allow []string, redact []string) []xlog.Field {
+	fs := make([]xlog.Field, 0, len(allow))
+	for _, name := range allow {
+		vals := headerValues(h, name)
+		if len(vals) == 0 {
+			continue
+		}
+		v := strings.Join(vals, ", ")
+		if containsFold(redact, name) {
+			v = Redacted
+		}
+		fs = append(fs, xlog.Str(strings.ToLower(name), v))
+	}
+	return fs
+}
+
+// headerValues is h.Values(name) that also finds non-canonical keys set by
+// assigning to the map directly.
+func headerValues(h http.Header, name string) []string {
+	if vals := h.Values(name); len(vals) > 0 {
+		return vals
+	}
+	for k, vals := range h {
+		if strings.EqualFold(k, name) {
+			return vals
+		}
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
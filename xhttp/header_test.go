@@ -0,0 +1,34 @@
+package xhttp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/trickstertwo/xlog"
+)
+
+func TestHeaderFields(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	h.Set("User-Agent", "curl/8.0")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+	h.Set("Authorization", "Bearer secret")
+	h["x-raw"] = []string{"direct"} // non-canonical key
+	h.Set("Cookie", "not allowed, not logged")
+
+	got := HeaderFields(h,
+		[]string{"user-agent", "ACCEPT", "Authorization", "X-Raw", "X-Missing"},
+		[]string{"authorization", "cookie"})
+	want := []xlog.Field{
+		xlog.Str("user-agent", "curl/8.0"),
+		xlog.Str("accept", "text/html, application/json"),
+		xlog.Str("authorization", Redacted),
+		xlog.Str("x-raw", "direct"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("HeaderFields =\n%+v\nwant\n%+v", got, want)
+	}
+}
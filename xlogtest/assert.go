@@ -0,0 +1,132 @@
+package xlogtest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// AssertLogged reports an error on t unless rec holds an entry with exactly
+// level and msg.
+func AssertLogged(t testing.TB, rec *Recorder, level xlog.Level, msg string) bool {
+	t.Helper()
+	entries := rec.Entries()
+	for _, e := range entries {
+		if e.Level == level && e.Message == msg {
+			return true
+		}
+	}
+	t.Errorf("xlogtest: no %s entry with message %q%s", level, msg, dump(entries))
+	return false
+}
+
+// AssertField reports an error on t unless some entry in rec has a field key
+// equal to value. Numbers compare across widths but not across signed,
+// unsigned and float kinds (3 matches Int64("n", 3), not Uint64("n", 3));
+// times compare with Equal; an error field matches the same error, an error
+// it wraps (errors.Is), or its message as a string.
+func AssertField(t testing.TB, rec *Recorder, key string, value any) bool {
+	t.Helper()
+	entries := rec.Entries()
+	for _, e := range entries {
+		for i := range e.Fields {
+			if e.Fields[i].K == key && fieldEquals(&e.Fields[i], value) {
+				return true
+			}
+		}
+	}
+	t.Errorf("xlogtest: no entry with field %s=%v%s", key, value, dump(entries))
+	return false
+}
+
+// AssertNoErrors reports an error on t if rec holds any entry at LevelError or
+// above.
+func AssertNoErrors(t testing.TB, rec *Recorder) bool {
+	t.Helper()
+	entries := rec.Entries()
+	for _, e := range entries {
+		if e.Level >= xlog.LevelError {
+			t.Errorf("xlogtest: unexpected %s entry %q%s", e.Level, e.Message, dump(entries))
+			return false
+		}
+	}
+	return true
+}
+
+// dump lists entries one logfmt line each, for failure messages.
+func dump(entries []xlog.Entry) string {
+	if len(entries) == 0 {
+		return "\nno entries recorded"
+	}
+	var b strings.Builder
+	b.WriteString("\nrecorded entries:")
+	for _, e := range entries {
+		b.WriteString("\n\t")
+		b.WriteString(xlog.FormatEntry(e, xlog.FormatLogfmt))
+	}
+	return b.String()
+}
+
+func fieldEquals(f *xlog.Field, want any) bool {
+	switch f.Kind {
+	case xlog.KindError:
+		switch w := want.(type) {
+		case nil:
+			return f.Err == nil
+		case string:
+			return f.Err != nil && f.Err.Error() == w
+		case error:
+			return errors.Is(f.Err, w)
+		}
+		return false
+	case xlog.KindTime:
+		w, ok := want.(time.Time)
+		return ok && f.Time.Equal(w)
+	}
+	return reflect.DeepEqual(normalize(nativeValue(f)), normalize(want))
+}
+
+// nativeValue returns the Go value held by f.
+func nativeValue(f *xlog.Field) any {
+	switch f.Kind {
+	case xlog.KindString:
+		return f.Str
+	case xlog.KindInt64:
+		return f.Int64
+	case xlog.KindUint64:
+		return f.Uint64
+	case xlog.KindFloat64, xlog.KindFloat32:
+		return f.Float64
+	case xlog.KindBool:
+		return f.Bool
+	case xlog.KindDuration:
+		return f.Dur
+	case xlog.KindBytes:
+		return f.Bytes
+	default:
+		return f.Any
+	}
+}
+
+// normalize widens numbers so values of different widths compare equal.
+func normalize(v any) any {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, ok := v.(time.Duration); ok {
+			return v
+		}
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint()
+	case reflect.Float32:
+		return float64(float32(rv.Float()))
+	case reflect.Float64:
+		return rv.Float()
+	}
+	return v
+}
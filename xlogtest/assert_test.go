@@ -0,0 +1,89 @@
+package xlogtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// fakeT records failures instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	errs []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errs = append(f.errs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions_Pass(t *testing.T) {
+	t.Parallel()
+
+	logger, rec := NewLogger()
+	errNotFound := errors.New("not found")
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger.With(xlog.Str("svc", "api")).Info().
+		Int("status", 200).
+		Uint64("bytes", 512).
+		Float32("ratio", 0.5).
+		Dur("took", time.Second).
+		Time("at", at).
+		Err(fmt.Errorf("lookup: %w", errNotFound)).
+		Msg("served")
+	logger.Debug().Msg("detail")
+
+	ft := &fakeT{}
+	AssertLogged(ft, rec, xlog.LevelInfo, "served")
+	AssertLogged(ft, rec, xlog.LevelDebug, "detail")
+	AssertField(ft, rec, "svc", "api")
+	AssertField(ft, rec, "status", 200)
+	AssertField(ft, rec, "bytes", uint(512))
+	AssertField(ft, rec, "ratio", float32(0.5))
+	AssertField(ft, rec, "took", time.Second)
+	AssertField(ft, rec, "at", at.In(time.FixedZone("X", 3600)))
+	AssertField(ft, rec, "error", errNotFound)
+	AssertField(ft, rec, "error", "lookup: not found")
+	AssertNoErrors(ft, rec)
+	if len(ft.errs) != 0 {
+		t.Fatalf("unexpected failures:\n%s", strings.Join(ft.errs, "\n"))
+	}
+}
+
+func TestAssertions_FailWithDump(t *testing.T) {
+	t.Parallel()
+
+	logger, rec := NewLogger()
+	logger.Error().Str("path", "/a").Msg("boom")
+
+	ft := &fakeT{}
+	if AssertLogged(ft, rec, xlog.LevelInfo, "boom") {
+		t.Error("AssertLogged passed on a level mismatch")
+	}
+	if AssertField(ft, rec, "path", "/b") {
+		t.Error("AssertField passed on a value mismatch")
+	}
+	if AssertNoErrors(ft, rec) {
+		t.Error("AssertNoErrors passed with an error entry")
+	}
+	if len(ft.errs) != 3 {
+		t.Fatalf("got %d failures, want 3: %q", len(ft.errs), ft.errs)
+	}
+	for _, msg := range ft.errs {
+		if !strings.Contains(msg, "recorded entries:") || !strings.Contains(msg, `msg=boom path=/a`) {
+			t.Errorf("failure message lacks the entry dump: %q", msg)
+		}
+	}
+
+	rec.Reset()
+	ft.errs = nil
+	AssertLogged(ft, rec, xlog.LevelError, "boom")
+	if len(ft.errs) != 1 || !strings.Contains(ft.errs[0], "no entries recorded") {
+		t.Fatalf("after Reset: %q", ft.errs)
+	}
+}
@@ -0,0 +1,57 @@
+package xlogtest
+
+import (
+	"sync"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// Recorder is an xlog.Observer that keeps every entry it sees, including the
+// logger's bound fields, for later assertions. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []xlog.Entry
+}
+
+// NewRecorder returns an empty Recorder. Register it with Builder.AddObserver,
+// or use NewLogger for a logger whose only sink is a Recorder.
+func NewRecorder() *Recorder { return &Recorder{} }
+
+// NewLogger returns a logger at LevelTrace that writes nothing and records
+// every entry in the returned Recorder.
+func NewLogger() (*xlog.Logger, *Recorder) {
+	rec := NewRecorder()
+	l, err := xlog.NewBuilder().
+		WithAdapter(xlog.ObserverAdapter()).
+		WithMinLevel(xlog.LevelTrace).
+		AddObserver(rec).
+		Build()
+	if err != nil {
+		panic(err) // unreachable: the adapter is set
+	}
+	return l, rec
+}
+
+// OnEvent implements xlog.Observer.
+func (r *Recorder) OnEvent(e xlog.Entry) {
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+}
+
+// OnConfig implements xlog.Observer.
+func (r *Recorder) OnConfig(xlog.ConfigChange) {}
+
+// Entries returns a copy of the recorded entries in emission order.
+func (r *Recorder) Entries() []xlog.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]xlog.Entry(nil), r.entries...)
+}
+
+// Reset discards the recorded entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
@@ -0,0 +1,93 @@
+// Package xlogtest provides helpers for testing code that logs through xlog.
+package xlogtest
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+// StableTimestamp replaces every entry timestamp written by StableAdapter.
+const StableTimestamp = "2000-01-01T00:00:00Z"
+
+// stableAdapter writes one JSON object per entry with a fixed timestamp and
+// keys sorted lexicographically, so output is byte-for-byte stable across runs.
+type stableAdapter struct {
+	mu    *sync.Mutex // shared with children so lines never interleave
+	w     io.Writer
+	bound []xlog.Field
+}
+
+// StableAdapter returns an Adapter for golden-file tests. Each entry becomes a
+// single JSON line containing "ts" (always StableTimestamp), "level", "msg" and
+// all bound and event fields, with keys sorted. Durations use their Go string
+// form and times are RFC3339Nano in UTC. A later field with a duplicate key
+// overwrites an earlier one.
+func StableAdapter(w io.Writer) xlog.Adapter {
+	return &stableAdapter{mu: new(sync.Mutex), w: w}
+}
+
+func (a *stableAdapter) With(fs []xlog.Field) xlog.Adapter {
+	child := *a
+	child.bound = append(append(make([]xlog.Field, 0, len(a.bound)+len(fs)), a.bound...), fs...)
+	return &child
+}
+
+func (a *stableAdapter) Log(level xlog.Level, msg string, _ time.Time, fields []xlog.Field) {
+	m := make(map[string]any, 3+len(a.bound)+len(fields))
+	for i := range a.bound {
+		m[a.bound[i].K] = stableValue(&a.bound[i])
+	}
+	for i := range fields {
+		m[fields[i].K] = stableValue(&fields[i])
+	}
+	m["ts"] = StableTimestamp
+	m["level"] = level.String()
+	m["msg"] = msg
+
+	// encoding/json sorts map keys, which is what makes the output stable.
+	b, err := json.Marshal(m)
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"ts": StableTimestamp, "level": level.String(), "msg": msg, "error": err.Error()})
+	}
+	b = append(b, '\n')
+
+	a.mu.Lock()
+	_, _ = a.w.Write(b)
+	a.mu.Unlock()
+}
+
+func stableValue(f *xlog.Field) any {
+	switch f.Kind {
+	case xlog.KindString:
+		return f.Str
+	case xlog.KindInt64:
+		return f.Int64
+	case xlog.KindUint64:
+		return f.Uint64
+	case xlog.KindFloat64:
+		return f.Float64
+	case xlog.KindFloat32:
+		return float32(f.Float64)
+	case xlog.KindBool:
+		return f.Bool
+	case xlog.KindDuration:
+		return f.Dur.String()
+	case xlog.KindTime:
+		return f.Time.UTC().Format(time.RFC3339Nano)
+	case xlog.KindError:
+		if f.Err == nil {
+			return nil
+		}
+		return f.Err.Error()
+	case xlog.KindBytes:
+		return string(f.Bytes)
+	case xlog.KindAny:
+		return f.Any
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package xlogtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trickstertwo/xlog"
+)
+
+func TestStableAdapter_DeterministicOutput(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := xlog.NewBuilder().WithAdapter(StableAdapter(&buf)).Build()
+	if err != nil {
+		t.Fatalf("build logger: %v", err)
+	}
+	child := logger.With(xlog.Str("svc", "api"))
+
+	child.Info().Int("status", 200).Str("path", "/a").Dur("took", 1500*time.Millisecond).Msg("done")
+	child.Warn().Str("path", "/a").Err(errors.New("slow")).Int("status", 200).Msg("done")
+
+	want := `{"level":"info","msg":"done","path":"/a","status":200,"svc":"api","took":"1.5s","ts":"2000-01-01T00:00:00Z"}
+{"error":"slow","level":"warn","msg":"done","path":"/a","status":200,"svc":"api","ts":"2000-01-01T00:00:00Z"}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unstable output:\n got: %s\nwant: %s", got, want)
+	}
+}